@@ -1,14 +1,22 @@
 package bootstrap
 
 import (
+	"fmt"
+
+	"github.com/digisata/auth-service/pkg/cache"
 	"github.com/digisata/auth-service/pkg/memcached"
 	"github.com/digisata/auth-service/pkg/mongo"
+	"github.com/digisata/auth-service/pkg/redis"
 )
 
+// Application holds the connections of the app. Only the cache of the configured
+// driver is connected, the other one is nil.
 type Application struct {
 	Cfg         *Config
 	Mongo       mongo.Client
 	MemcachedDB *memcached.Database
+	RedisDB     *redis.Database
+	MemoryCache *cache.Memory
 }
 
 func App() (*Application, error) {
@@ -23,9 +31,22 @@ func App() (*Application, error) {
 	}
 
 	app := &Application{
-		Cfg:         cfg,
-		Mongo:       mongo,
-		MemcachedDB: memcached.NewDatabase(cfg.Memcached),
+		Cfg:   cfg,
+		Mongo: mongo,
+	}
+
+	switch cfg.Cache.Driver {
+	case "", cache.DRIVER_MEMCACHED:
+		app.MemcachedDB = memcached.NewDatabase(cfg.Memcached)
+	case cache.DRIVER_REDIS:
+		app.RedisDB, err = redis.NewDatabase(cfg.Redis)
+		if err != nil {
+			return nil, err
+		}
+	case cache.DRIVER_MEMORY:
+		app.MemoryCache = cache.NewMemory(cfg.Cache, nil)
+	default:
+		return nil, fmt.Errorf("unknown cache driver: %s", cfg.Cache.Driver)
 	}
 
 	return app, nil
@@ -33,4 +54,12 @@ func App() (*Application, error) {
 
 func (app Application) CloseDBConnection() {
 	CloseMongoDBConnection(app.Mongo)
+
+	if app.RedisDB != nil {
+		app.RedisDB.Close()
+	}
+
+	if app.MemoryCache != nil {
+		app.MemoryCache.Close()
+	}
 }
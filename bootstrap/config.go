@@ -4,10 +4,21 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/digisata/auth-service/pkg/cache"
 	"github.com/digisata/auth-service/pkg/grpcserver"
 	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/lockout"
+	"github.com/digisata/auth-service/pkg/mailer"
 	"github.com/digisata/auth-service/pkg/memcached"
 	"github.com/digisata/auth-service/pkg/mongo"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/portal"
+	"github.com/digisata/auth-service/pkg/ratelimit"
+	"github.com/digisata/auth-service/pkg/rbac"
+	"github.com/digisata/auth-service/pkg/redis"
+	"github.com/digisata/auth-service/pkg/totp"
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
@@ -17,7 +28,17 @@ type Config struct {
 	ContextTimeout int               `mapstructure:"CONTEXT_TIMEOUT"`
 	Jwt            jwtio.Config      `mapstructure:"JWT"`
 	Mongo          mongo.Config      `mapstructure:"MONGO"`
+	Cache          cache.Config      `mapstructure:"CACHE"`
 	Memcached      memcached.Config  `mapstructure:"MEMCACHED"`
+	Redis          redis.Config      `mapstructure:"REDIS"`
+	OAuth          oauth.Config      `mapstructure:"OAUTH"`
+	MFA            totp.Config       `mapstructure:"MFA"`
+	Mailer         mailer.Config     `mapstructure:"MAILER"`
+	Lockout        lockout.Config    `mapstructure:"LOCKOUT"`
+	Password       password.Config   `mapstructure:"PASSWORD"`
+	Portals        portal.Config     `mapstructure:"PORTALS"`
+	Roles          rbac.Config       `mapstructure:"ROLES"`
+	RateLimit      ratelimit.Config  `mapstructure:"RATE_LIMIT"`
 	GrpcServer     grpcserver.Config `mapstructure:"GRPC_SERVER"`
 }
 
@@ -45,3 +66,20 @@ func LoadConfig() (*Config, error) {
 
 	return &cfg, nil
 }
+
+// WatchConfig re-reads the config file whenever it changes and hands the new
+// config to onChange, e.g. to pick up rotated signing keys without a restart.
+func WatchConfig(onChange func(cfg *Config)) {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		cfg := Config{}
+
+		err := viper.Unmarshal(&cfg)
+		if err != nil {
+			log.Printf("environment can't be reloaded: %v", err)
+			return
+		}
+
+		onChange(&cfg)
+	})
+	viper.WatchConfig()
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"math"
 
 	"github.com/digisata/auth-service/domain"
 	"github.com/digisata/auth-service/stubs"
@@ -11,18 +12,52 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type AuthController struct {
 	stubs.UnimplementedAuthServiceServer
 	UserUsecase    UserUsecase
 	ProfileUsecase ProfileUsecase
+	AccountUsecase AccountUsecase
+	MFAUsecase     MFAUsecase
+	ClientUsecase  ClientUsecase
+	RoleUsecase    RoleUsecase
+	OAuthUsecase   OAuthUsecase
 }
 
 var _ UserUsecase = (*usecase.UserUsecase)(nil)
 var _ ProfileUsecase = (*usecase.ProfileUsecase)(nil)
+var _ AccountUsecase = (*usecase.AccountUsecase)(nil)
+var _ MFAUsecase = (*usecase.MFAUsecase)(nil)
+var _ ClientUsecase = (*usecase.ClientUsecase)(nil)
+var _ RoleUsecase = (*usecase.RoleUsecase)(nil)
+var _ OAuthUsecase = (*usecase.OAuthUsecase)(nil)
 
 // User
+func (c AuthController) Login(ctx context.Context, req *stubs.PortalLoginRequest) (*stubs.LoginResponse, error) {
+	payload := domain.LoginRequest{
+		Portal:   req.GetPortal(),
+		Email:    req.GetEmail(),
+		Password: req.GetPassword(),
+	}
+
+	data, err := c.UserUsecase.Login(ctx, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.LoginResponse{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+		MfaRequired:  data.MFARequired,
+		MfaToken:     data.MFAToken,
+	}
+
+	return res, nil
+}
+
 func (c AuthController) LoginAdmin(ctx context.Context, req *stubs.LoginRequest) (*stubs.LoginResponse, error) {
 	payload := domain.User{
 		Email:    req.GetEmail(),
@@ -37,6 +72,9 @@ func (c AuthController) LoginAdmin(ctx context.Context, req *stubs.LoginRequest)
 	res := &stubs.LoginResponse{
 		AccessToken:  data.AccessToken,
 		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+		MfaRequired:  data.MFARequired,
+		MfaToken:     data.MFAToken,
 	}
 
 	return res, nil
@@ -56,6 +94,9 @@ func (c AuthController) LoginCustomer(ctx context.Context, req *stubs.LoginReque
 	res := &stubs.LoginResponse{
 		AccessToken:  data.AccessToken,
 		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+		MfaRequired:  data.MFARequired,
+		MfaToken:     data.MFAToken,
 	}
 
 	return res, nil
@@ -75,6 +116,9 @@ func (c AuthController) LoginCommittee(ctx context.Context, req *stubs.LoginRequ
 	res := &stubs.LoginResponse{
 		AccessToken:  data.AccessToken,
 		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+		MfaRequired:  data.MFARequired,
+		MfaToken:     data.MFAToken,
 	}
 
 	return res, nil
@@ -94,12 +138,17 @@ func (c AuthController) RefreshToken(ctx context.Context, req *stubs.RefreshToke
 	res := &stubs.RefreshTokenResponse{
 		AccessToken:  data.AccessToken,
 		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
 	}
 
 	return res, nil
 }
 
 func (c AuthController) CreateUser(ctx context.Context, req *stubs.CreateUserRequest) (*stubs.BaseResponse, error) {
+	if req.GetRole() < 1 || req.GetRole() > math.MaxInt8 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid role")
+	}
+
 	user := domain.User{
 		ID:       primitive.NewObjectID(),
 		Name:     req.GetName(),
@@ -177,9 +226,14 @@ func (c AuthController) UpdateUser(ctx context.Context, req *stubs.UpdateUserReq
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	if req.GetRole() < 0 || req.GetRole() > math.MaxInt8 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid role")
+	}
+
 	user := domain.UpdateUser{
 		ID:       idHex,
 		Name:     req.GetName(),
+		Role:     int8(req.GetRole()),
 		IsActive: req.GetIsActive(),
 		Note:     req.GetNote(),
 	}
@@ -218,6 +272,37 @@ func (c AuthController) DeleteUser(ctx context.Context, req *stubs.DeleteUserReq
 	return res, nil
 }
 
+func (c AuthController) UnlockUser(ctx context.Context, req *stubs.UnlockUserRequest) (*stubs.BaseResponse, error) {
+	err := c.UserUsecase.Unlock(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ChangeUserPassword(ctx context.Context, req *stubs.ChangeUserPasswordRequest) (*stubs.BaseResponse, error) {
+	changeUserPasswordRequest := domain.ChangeUserPasswordRequest{
+		ID:          req.GetId(),
+		NewPassword: req.GetNewPassword(),
+	}
+
+	err := c.UserUsecase.ChangePassword(ctx, changeUserPasswordRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
 func (c AuthController) Logout(ctx context.Context, req *stubs.LogoutRequest) (*stubs.BaseResponse, error) {
 	err := c.UserUsecase.Logout(ctx, req.GetRefreshToken())
 	if err != nil {
@@ -269,3 +354,587 @@ func (c AuthController) ChangePassword(ctx context.Context, req *stubs.ChangePas
 
 	return res, nil
 }
+
+func (c AuthController) ListMySessions(ctx context.Context, req *emptypb.Empty) (*stubs.ListSessionsResponse, error) {
+	data, err := c.ProfileUsecase.ListSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*stubs.Session, 0, len(data))
+	for _, val := range data {
+		sessions = append(sessions, &stubs.Session{
+			Id:         val.ID.Hex(),
+			UserAgent:  val.UserAgent,
+			Ip:         val.IP,
+			CreatedAt:  int32(val.CreatedAt),
+			LastUsedAt: int32(val.LastUsedAt),
+			Current:    val.Current,
+		})
+	}
+
+	res := &stubs.ListSessionsResponse{
+		Sessions: sessions,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RevokeMySession(ctx context.Context, req *stubs.RevokeSessionRequest) (*stubs.BaseResponse, error) {
+	err := c.ProfileUsecase.RevokeSession(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RevokeOtherSessions(ctx context.Context, req *emptypb.Empty) (*stubs.BaseResponse, error) {
+	err := c.ProfileUsecase.RevokeOtherSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+// Account
+func (c AuthController) SendVerificationEmail(ctx context.Context, req *emptypb.Empty) (*stubs.BaseResponse, error) {
+	err := c.AccountUsecase.SendVerificationEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) VerifyEmail(ctx context.Context, req *stubs.TokenOnlyRequest) (*stubs.BaseResponse, error) {
+	err := c.AccountUsecase.VerifyEmail(ctx, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RequestPasswordReset(ctx context.Context, req *stubs.RequestPasswordResetRequest) (*stubs.BaseResponse, error) {
+	err := c.AccountUsecase.RequestPasswordReset(ctx, req.GetEmail())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ResetPassword(ctx context.Context, req *stubs.ResetPasswordRequest) (*stubs.BaseResponse, error) {
+	resetPasswordRequest := domain.ResetPasswordRequest{
+		Token:       req.GetToken(),
+		NewPassword: req.GetNewPassword(),
+	}
+
+	err := c.AccountUsecase.ResetPassword(ctx, resetPasswordRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RequestMagicLink(ctx context.Context, req *stubs.RequestMagicLinkRequest) (*stubs.BaseResponse, error) {
+	err := c.AccountUsecase.RequestMagicLink(ctx, req.GetEmail())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ConsumeMagicLink(ctx context.Context, req *stubs.TokenOnlyRequest) (*stubs.LoginResponse, error) {
+	data, err := c.AccountUsecase.ConsumeMagicLink(ctx, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.LoginResponse{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+		MfaRequired:  data.MFARequired,
+		MfaToken:     data.MFAToken,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) CheckPasswordStrength(ctx context.Context, req *stubs.CheckPasswordStrengthRequest) (*stubs.CheckPasswordStrengthResponse, error) {
+	data := c.AccountUsecase.CheckPasswordStrength(ctx, domain.CheckPasswordStrengthRequest{
+		Password: req.GetPassword(),
+		Name:     req.GetName(),
+		Email:    req.GetEmail(),
+	})
+
+	violations := make([]*stubs.PasswordViolation, 0, len(data.Violations))
+	for _, val := range data.Violations {
+		violations = append(violations, &stubs.PasswordViolation{
+			Rule:        val.Rule,
+			Description: val.Description,
+		})
+	}
+
+	res := &stubs.CheckPasswordStrengthResponse{
+		Valid:      data.Valid,
+		Violations: violations,
+	}
+
+	return res, nil
+}
+
+// MFA
+func (c AuthController) EnrollTOTP(ctx context.Context, req *emptypb.Empty) (*stubs.EnrollTOTPResponse, error) {
+	data, err := c.MFAUsecase.EnrollTOTP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.EnrollTOTPResponse{
+		Secret:        data.Secret,
+		Uri:           data.URI,
+		RecoveryCodes: data.RecoveryCodes,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ConfirmTOTP(ctx context.Context, req *stubs.TOTPCodeRequest) (*stubs.BaseResponse, error) {
+	err := c.MFAUsecase.ConfirmTOTP(ctx, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) DisableTOTP(ctx context.Context, req *stubs.TOTPCodeRequest) (*stubs.BaseResponse, error) {
+	err := c.MFAUsecase.DisableTOTP(ctx, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RegenerateRecoveryCodes(ctx context.Context, req *stubs.TOTPCodeRequest) (*stubs.RecoveryCodesResponse, error) {
+	data, err := c.MFAUsecase.RegenerateRecoveryCodes(ctx, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.RecoveryCodesResponse{
+		RecoveryCodes: data,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) VerifyMFA(ctx context.Context, req *stubs.VerifyMFARequest) (*stubs.LoginResponse, error) {
+	verifyMFARequest := domain.VerifyMFARequest{
+		MFAToken:     req.GetMfaToken(),
+		Code:         req.GetCode(),
+		RecoveryCode: req.GetRecoveryCode(),
+	}
+
+	data, err := c.MFAUsecase.VerifyMFA(ctx, verifyMFARequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.LoginResponse{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+	}
+
+	return res, nil
+}
+
+// Client
+func toClientResponse(client domain.Client) *stubs.Client {
+	return &stubs.Client{
+		ClientId:               client.ClientID,
+		Name:                   client.Name,
+		IsPublic:               client.IsPublic,
+		GrantTypes:             client.GrantTypes,
+		RedirectUris:           client.RedirectURIs,
+		Scopes:                 client.Scopes,
+		AccessTokenExpiryHour:  int32(client.AccessTokenExpiryHour),
+		RefreshTokenExpiryHour: int32(client.RefreshTokenExpiryHour),
+		CreatedAt:              int32(client.CreatedAt),
+		UpdatedAt:              int32(client.UpdatedAt),
+	}
+}
+
+func (c AuthController) CreateClient(ctx context.Context, req *stubs.CreateClientRequest) (*stubs.CreateClientResponse, error) {
+	client := domain.Client{
+		Name:                   req.GetName(),
+		IsPublic:               req.GetIsPublic(),
+		GrantTypes:             req.GetGrantTypes(),
+		RedirectURIs:           req.GetRedirectUris(),
+		Scopes:                 req.GetScopes(),
+		AccessTokenExpiryHour:  int(req.GetAccessTokenExpiryHour()),
+		RefreshTokenExpiryHour: int(req.GetRefreshTokenExpiryHour()),
+	}
+
+	data, err := c.ClientUsecase.Create(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.CreateClientResponse{
+		Client:       toClientResponse(data.Client),
+		ClientSecret: data.ClientSecret,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ListClients(ctx context.Context, req *emptypb.Empty) (*stubs.ListClientsResponse, error) {
+	clients, err := c.ClientUsecase.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.ListClientsResponse{}
+	for _, client := range clients {
+		res.Clients = append(res.Clients, toClientResponse(client))
+	}
+
+	return res, nil
+}
+
+// updatedList returns the list to store, nil leaves the stored one alone. An
+// empty list only clears the field when the update mask names it.
+func updatedList(mask *fieldmaskpb.FieldMask, path string, values []string) *[]string {
+	if len(values) == 0 {
+		masked := false
+		for _, val := range mask.GetPaths() {
+			if val == path {
+				masked = true
+				break
+			}
+		}
+
+		if !masked {
+			return nil
+		}
+
+		values = []string{}
+	}
+
+	return &values
+}
+
+func (c AuthController) UpdateClient(ctx context.Context, req *stubs.UpdateClientRequest) (*stubs.BaseResponse, error) {
+	client := domain.UpdateClient{
+		ClientID:               req.GetClientId(),
+		Name:                   req.GetName(),
+		GrantTypes:             updatedList(req.GetUpdateMask(), "grant_types", req.GetGrantTypes()),
+		RedirectURIs:           updatedList(req.GetUpdateMask(), "redirect_uris", req.GetRedirectUris()),
+		Scopes:                 updatedList(req.GetUpdateMask(), "scopes", req.GetScopes()),
+		AccessTokenExpiryHour:  int(req.GetAccessTokenExpiryHour()),
+		RefreshTokenExpiryHour: int(req.GetRefreshTokenExpiryHour()),
+	}
+
+	err := c.ClientUsecase.Update(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RotateClientSecret(ctx context.Context, req *stubs.RotateClientSecretRequest) (*stubs.RotateClientSecretResponse, error) {
+	data, err := c.ClientUsecase.RotateSecret(ctx, req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.RotateClientSecretResponse{
+		ClientId:     data.ClientID,
+		ClientSecret: data.ClientSecret,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) DeleteClient(ctx context.Context, req *stubs.DeleteClientRequest) (*stubs.BaseResponse, error) {
+	err := c.ClientUsecase.Delete(ctx, req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+// Role
+func toRoleResponse(role domain.Role) *stubs.Role {
+	return &stubs.Role{
+		Id:          role.ID.Hex(),
+		Code:        int32(role.Code),
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		CreatedAt:   int32(role.CreatedAt),
+		UpdatedAt:   int32(role.UpdatedAt),
+	}
+}
+
+func (c AuthController) CreateRole(ctx context.Context, req *stubs.CreateRoleRequest) (*stubs.RoleResponse, error) {
+	role := domain.Role{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Permissions: req.GetPermissions(),
+	}
+
+	data, err := c.RoleUsecase.Create(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.RoleResponse{
+		Role: toRoleResponse(data),
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ListRoles(ctx context.Context, req *emptypb.Empty) (*stubs.ListRolesResponse, error) {
+	roles, err := c.RoleUsecase.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.ListRolesResponse{}
+	for _, role := range roles {
+		res.Roles = append(res.Roles, toRoleResponse(role))
+	}
+
+	return res, nil
+}
+
+func (c AuthController) UpdateRole(ctx context.Context, req *stubs.UpdateRoleRequest) (*stubs.BaseResponse, error) {
+	idHex, err := primitive.ObjectIDFromHex(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	role := domain.UpdateRole{
+		ID:          idHex,
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	}
+
+	err = c.RoleUsecase.Update(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) GrantPermission(ctx context.Context, req *stubs.RolePermissionRequest) (*stubs.BaseResponse, error) {
+	rolePermissionRequest := domain.RolePermissionRequest{
+		ID:         req.GetId(),
+		Permission: req.GetPermission(),
+	}
+
+	err := c.RoleUsecase.GrantPermission(ctx, rolePermissionRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) RevokePermission(ctx context.Context, req *stubs.RolePermissionRequest) (*stubs.BaseResponse, error) {
+	rolePermissionRequest := domain.RolePermissionRequest{
+		ID:         req.GetId(),
+		Permission: req.GetPermission(),
+	}
+
+	err := c.RoleUsecase.RevokePermission(ctx, rolePermissionRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.BaseResponse{
+		Message: "Success",
+	}
+
+	return res, nil
+}
+
+func (c AuthController) ListPermissions(ctx context.Context, req *emptypb.Empty) (*stubs.ListPermissionsResponse, error) {
+	permissions, err := c.RoleUsecase.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.ListPermissionsResponse{}
+	for _, permission := range permissions {
+		res.Permissions = append(res.Permissions, &stubs.Permission{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return res, nil
+}
+
+// OAuth
+func (c AuthController) IntrospectToken(ctx context.Context, req *stubs.IntrospectTokenRequest) (*stubs.IntrospectTokenResponse, error) {
+	introspectTokenRequest := domain.IntrospectTokenRequest{
+		Token:         req.GetToken(),
+		TokenTypeHint: req.GetTokenTypeHint(),
+	}
+
+	data, err := c.OAuthUsecase.IntrospectToken(ctx, introspectTokenRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.IntrospectTokenResponse{
+		Active:    data.Active,
+		Sub:       data.Sub,
+		Role:      int32(data.Role),
+		Exp:       data.Exp,
+		Iat:       data.Iat,
+		TokenType: data.TokenType,
+		ClientId:  data.ClientID,
+		Scope:     data.Scope,
+		SubType:   data.SubType,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) UserInfo(ctx context.Context, req *emptypb.Empty) (*stubs.UserInfoResponse, error) {
+	data, err := c.OAuthUsecase.UserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.UserInfoResponse{
+		Sub:           data.Sub,
+		Name:          data.Name,
+		Email:         data.Email,
+		EmailVerified: data.EmailVerified,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) Authorize(ctx context.Context, req *stubs.AuthorizeRequest) (*stubs.AuthorizeResponse, error) {
+	authorizeRequest := domain.AuthorizeRequest{
+		ResponseType:        req.GetResponseType(),
+		ClientID:            req.GetClientId(),
+		RedirectURI:         req.GetRedirectUri(),
+		Scope:               req.GetScope(),
+		State:               req.GetState(),
+		CodeChallenge:       req.GetCodeChallenge(),
+		CodeChallengeMethod: req.GetCodeChallengeMethod(),
+		Nonce:               req.GetNonce(),
+	}
+
+	data, err := c.OAuthUsecase.Authorize(ctx, authorizeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.AuthorizeResponse{
+		Code:       data.Code,
+		State:      data.State,
+		RedirectTo: data.RedirectTo,
+	}
+
+	return res, nil
+}
+
+func (c AuthController) Token(ctx context.Context, req *stubs.TokenRequest) (*stubs.TokenResponse, error) {
+	tokenRequest := domain.TokenRequest{
+		GrantType:    req.GetGrantType(),
+		Code:         req.GetCode(),
+		RedirectURI:  req.GetRedirectUri(),
+		ClientID:     req.GetClientId(),
+		ClientSecret: req.GetClientSecret(),
+		CodeVerifier: req.GetCodeVerifier(),
+		Scope:        req.GetScope(),
+	}
+
+	data, err := c.OAuthUsecase.Token(ctx, tokenRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &stubs.TokenResponse{
+		AccessToken:  data.AccessToken,
+		TokenType:    domain.TOKEN_TYPE_BEARER,
+		ExpiresIn:    int32(data.ExpiresIn),
+		RefreshToken: data.RefreshToken,
+		IdToken:      data.IDToken,
+		Scope:        data.Scope,
+	}
+
+	return res, nil
+}
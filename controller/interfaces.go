@@ -8,6 +8,7 @@ import (
 
 type (
 	UserUsecase interface {
+		Login(ctx context.Context, req domain.LoginRequest) (domain.AuthResponse, error)
 		LoginAdmin(ctx context.Context, req domain.User) (domain.AuthResponse, error)
 		LoginCustomer(ctx context.Context, req domain.User) (domain.AuthResponse, error)
 		LoginCommittee(ctx context.Context, req domain.User) (domain.AuthResponse, error)
@@ -17,11 +18,58 @@ type (
 		GetByID(ctx context.Context, id string) (domain.User, error)
 		Update(ctx context.Context, req domain.UpdateUser) error
 		Delete(ctx context.Context, req domain.DeleteUser) error
+		Unlock(ctx context.Context, id string) error
+		ChangePassword(ctx context.Context, req domain.ChangeUserPasswordRequest) error
 		Logout(ctx context.Context, refreshToken string) error
 	}
 
 	ProfileUsecase interface {
 		GetByID(ctx context.Context, id string) (domain.Profile, error)
 		ChangePassword(ctx context.Context, req domain.ChangePasswordRequest) error
+		ListSessions(ctx context.Context) ([]domain.SessionResponse, error)
+		RevokeSession(ctx context.Context, id string) error
+		RevokeOtherSessions(ctx context.Context) error
+	}
+
+	AccountUsecase interface {
+		SendVerificationEmail(ctx context.Context) error
+		VerifyEmail(ctx context.Context, token string) error
+		RequestPasswordReset(ctx context.Context, email string) error
+		ResetPassword(ctx context.Context, req domain.ResetPasswordRequest) error
+		RequestMagicLink(ctx context.Context, email string) error
+		ConsumeMagicLink(ctx context.Context, token string) (domain.AuthResponse, error)
+		CheckPasswordStrength(ctx context.Context, req domain.CheckPasswordStrengthRequest) domain.CheckPasswordStrengthResponse
+	}
+
+	MFAUsecase interface {
+		EnrollTOTP(ctx context.Context) (domain.EnrollTOTPResponse, error)
+		ConfirmTOTP(ctx context.Context, code string) error
+		DisableTOTP(ctx context.Context, code string) error
+		RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error)
+		VerifyMFA(ctx context.Context, req domain.VerifyMFARequest) (domain.AuthResponse, error)
+	}
+
+	ClientUsecase interface {
+		Create(ctx context.Context, req domain.Client) (domain.CreateClientResponse, error)
+		GetAll(ctx context.Context) ([]domain.Client, error)
+		Update(ctx context.Context, req domain.UpdateClient) error
+		RotateSecret(ctx context.Context, clientID string) (domain.RotateClientSecretResponse, error)
+		Delete(ctx context.Context, clientID string) error
+	}
+
+	RoleUsecase interface {
+		Create(ctx context.Context, req domain.Role) (domain.Role, error)
+		GetAll(ctx context.Context) ([]domain.Role, error)
+		Update(ctx context.Context, req domain.UpdateRole) error
+		GrantPermission(ctx context.Context, req domain.RolePermissionRequest) error
+		RevokePermission(ctx context.Context, req domain.RolePermissionRequest) error
+		ListPermissions(ctx context.Context) ([]domain.Permission, error)
+	}
+
+	OAuthUsecase interface {
+		IntrospectToken(ctx context.Context, req domain.IntrospectTokenRequest) (domain.IntrospectTokenResponse, error)
+		UserInfo(ctx context.Context) (domain.UserInfo, error)
+		Authorize(ctx context.Context, req domain.AuthorizeRequest) (domain.AuthorizeResponse, error)
+		Token(ctx context.Context, req domain.TokenRequest) (domain.AuthResponse, error)
 	}
 )
@@ -0,0 +1,17 @@
+package domain
+
+const (
+	PURPOSE_VERIFY_EMAIL string = "verify_email"
+	PURPOSE_MAGIC_LINK   string = "magic_link"
+
+	EMAIL_VERIFICATION_KEY_PREFIX string = "email_verification:"
+	PASSWORD_RESET_KEY_PREFIX     string = "password_reset:"
+	MAGIC_LINK_KEY_PREFIX         string = "magic_link:"
+)
+
+type (
+	ResetPasswordRequest struct {
+		Token       string
+		NewPassword string
+	}
+)
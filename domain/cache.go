@@ -1,6 +1,13 @@
 package domain
 
+import "errors"
+
+// ErrCacheMiss is returned by cache repositories for keys that are not cached
+var ErrCacheMiss = errors.New("cache miss")
+
 type (
+	// CacheItem is a cached value, Exp is the unix time it expires at or 0 for
+	// values that never expire
 	CacheItem struct {
 		Key   string
 		Value string
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CLIENT_COLLECTION string = "clients"
+)
+
+// GRANT_TYPES are the grant types a client may be registered for
+var GRANT_TYPES = []string{
+	GRANT_TYPE_AUTHORIZATION_CODE,
+	GRANT_TYPE_CLIENT_CREDENTIALS,
+}
+
+type (
+	// Client is an application registered to use the oauth endpoints. SecretHash is
+	// the bcrypt hash of the client secret, public clients such as SPAs and mobile
+	// apps have none and must use PKCE.
+	Client struct {
+		ID                     primitive.ObjectID `bson:"_id"`
+		ClientID               string             `bson:"client_id"`
+		Name                   string             `bson:"name"`
+		SecretHash             string             `bson:"secret_hash"`
+		IsPublic               bool               `bson:"is_public"`
+		GrantTypes             []string           `bson:"grant_types"`
+		RedirectURIs           []string           `bson:"redirect_uris"`
+		Scopes                 []string           `bson:"scopes"`
+		AccessTokenExpiryHour  int                `bson:"access_token_expiry_hour"`
+		RefreshTokenExpiryHour int                `bson:"refresh_token_expiry_hour"`
+		CreatedAt              int64              `bson:"created_at"`
+		UpdatedAt              int64              `bson:"updated_at"`
+	}
+
+	CreateClientResponse struct {
+		Client       Client
+		ClientSecret string
+	}
+
+	// UpdateClient leaves nil lists alone, a list that is set replaces the stored
+	// one even when empty
+	UpdateClient struct {
+		ClientID               string    `bson:"client_id"`
+		Name                   string    `bson:"name,omitempty"`
+		GrantTypes             *[]string `bson:"grant_types,omitempty"`
+		RedirectURIs           *[]string `bson:"redirect_uris,omitempty"`
+		Scopes                 *[]string `bson:"scopes,omitempty"`
+		AccessTokenExpiryHour  int       `bson:"access_token_expiry_hour,omitempty"`
+		RefreshTokenExpiryHour int       `bson:"refresh_token_expiry_hour,omitempty"`
+		UpdatedAt              int64     `bson:"updated_at,omitempty"`
+	}
+
+	RotateClientSecretResponse struct {
+		ClientID     string
+		ClientSecret string
+	}
+)
+
+// HasRedirectURI reports whether uri exactly matches a registered redirect uri
+func (c Client) HasRedirectURI(uri string) bool {
+	return contains(c.RedirectURIs, uri)
+}
+
+func (c Client) HasGrantType(grantType string) bool {
+	return contains(c.GrantTypes, grantType)
+}
+
+// HasScopes reports whether every requested scope is allowed for the client
+func (c Client) HasScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !contains(c.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
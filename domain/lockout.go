@@ -0,0 +1,8 @@
+package domain
+
+const (
+	LOGIN_FAILURES_KEY_PREFIX   string = "login_failures:"
+	LOGIN_LOCK_KEY_PREFIX       string = "login_lock:"
+	LOGIN_LOCKOUTS_KEY_PREFIX   string = "login_lockouts:"
+	LOGIN_GENERATION_KEY_PREFIX string = "login_generation:"
+)
@@ -0,0 +1,41 @@
+package domain
+
+const (
+	MFA_CHALLENGE_KEY_PREFIX string = "mfa_challenge:"
+	MFA_ATTEMPTS_KEY_PREFIX  string = "mfa_attempts:"
+	TOTP_USED_KEY_PREFIX     string = "totp_used:"
+
+	// MFA_MAX_ATTEMPTS is how many codes may be checked against a challenge
+	MFA_MAX_ATTEMPTS int = 5
+)
+
+type (
+	EnrollTOTPResponse struct {
+		Secret        string
+		URI           string
+		RecoveryCodes []string
+	}
+
+	// MFAChallenge is what a challenge token stands for while it waits in the cache
+	MFAChallenge struct {
+		UserID   string `json:"user_id"`
+		Audience string `json:"aud,omitempty"`
+		Exp      int64  `json:"exp"`
+	}
+
+	// VerifyMFARequest carries either a TOTP code or a recovery code
+	VerifyMFARequest struct {
+		MFAToken     string
+		Code         string
+		RecoveryCode string
+	}
+
+	// UpdateMFA sets every field on purpose, an empty secret clears enrollment.
+	// Recovery codes are stored as sha256 hashes.
+	UpdateMFA struct {
+		TOTPSecret    string   `bson:"totp_secret"`
+		MFAEnabled    bool     `bson:"mfa_enabled"`
+		RecoveryCodes []string `bson:"recovery_codes"`
+		UpdatedAt     int64    `bson:"updated_at,omitempty"`
+	}
+)
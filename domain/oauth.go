@@ -0,0 +1,82 @@
+package domain
+
+const (
+	ACCESS_TOKEN  string = "access_token"
+	REFRESH_TOKEN string = "refresh_token"
+
+	RESPONSE_TYPE_CODE            string = "code"
+	GRANT_TYPE_AUTHORIZATION_CODE string = "authorization_code"
+	GRANT_TYPE_CLIENT_CREDENTIALS string = "client_credentials"
+	TOKEN_TYPE_BEARER             string = "Bearer"
+
+	// SCOPE_INTROSPECT lets a client introspect tokens issued to others
+	SCOPE_INTROSPECT string = "introspect"
+
+	AUTHORIZATION_CODE_KEY_PREFIX string = "authorization_code:"
+)
+
+type (
+	IntrospectTokenRequest struct {
+		Token         string
+		TokenTypeHint string
+	}
+
+	// IntrospectTokenResponse follows the RFC 7662 introspection response
+	IntrospectTokenResponse struct {
+		Active    bool
+		Sub       string
+		Role      int8
+		Exp       int64
+		Iat       int64
+		TokenType string
+		ClientID  string
+		Scope     string
+		SubType   string
+	}
+
+	AuthorizeRequest struct {
+		ResponseType        string
+		ClientID            string
+		RedirectURI         string
+		Scope               string
+		State               string
+		CodeChallenge       string
+		CodeChallengeMethod string
+		Nonce               string
+	}
+
+	AuthorizeResponse struct {
+		Code       string
+		State      string
+		RedirectTo string
+	}
+
+	// AuthorizationCode is what an issued code is bound to while it waits in the cache
+	AuthorizationCode struct {
+		ClientID            string `json:"client_id"`
+		RedirectURI         string `json:"redirect_uri"`
+		UserID              string `json:"user_id"`
+		Scope               string `json:"scope"`
+		Nonce               string `json:"nonce"`
+		CodeChallenge       string `json:"code_challenge"`
+		CodeChallengeMethod string `json:"code_challenge_method"`
+	}
+
+	TokenRequest struct {
+		GrantType    string
+		Code         string
+		RedirectURI  string
+		ClientID     string
+		ClientSecret string
+		CodeVerifier string
+		Scope        string
+	}
+
+	// UserInfo holds the OpenID Connect standard claims of the user
+	UserInfo struct {
+		Sub           string
+		Name          string
+		Email         string
+		EmailVerified bool
+	}
+)
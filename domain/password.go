@@ -0,0 +1,19 @@
+package domain
+
+type (
+	CheckPasswordStrengthRequest struct {
+		Password string
+		Name     string
+		Email    string
+	}
+
+	PasswordViolation struct {
+		Rule        string
+		Description string
+	}
+
+	CheckPasswordStrengthResponse struct {
+		Valid      bool
+		Violations []PasswordViolation
+	}
+)
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const PASSWORD_HISTORY_COLLECTION string = "password_histories"
+
+type (
+	// PasswordHistory keeps the previous password hashes of a user apart from the
+	// user, newest last
+	PasswordHistory struct {
+		UserID    primitive.ObjectID `bson:"user_id"`
+		Passwords []string           `bson:"passwords"`
+		UpdatedAt int64              `bson:"updated_at"`
+	}
+
+	ChangeUserPasswordRequest struct {
+		ID          string
+		NewPassword string
+	}
+)
@@ -9,6 +9,7 @@ type (
 		ID        primitive.ObjectID `bson:"_id"`
 		Name      string             `bson:"name"`
 		Email     string             `bson:"email"`
+		Role      int8               `bson:"role"`
 		Password  string             `bson:"password"`
 		CreatedAt int64              `bson:"created_at"`
 		UpdatedAt int64              `bson:"updated_at"`
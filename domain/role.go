@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"github.com/digisata/auth-service/pkg/constants"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ROLE_COLLECTION       string = "roles"
+	PERMISSION_COLLECTION string = "permissions"
+
+	// ROLE_CREATE_ATTEMPTS is how often a role is tried under the next free code
+	// when another role took that code first
+	ROLE_CREATE_ATTEMPTS int = 5
+)
+
+// PERMISSIONS describe the permissions of this service, they are seeded into the
+// permissions collection on start
+var PERMISSIONS = []Permission{
+	{Name: constants.PERMISSION_USERS_READ, Description: "Read users"},
+	{Name: constants.PERMISSION_USERS_WRITE, Description: "Create, update, delete and unlock users and change their password"},
+	{Name: constants.PERMISSION_CLIENTS_MANAGE, Description: "Register and manage oauth clients"},
+	{Name: constants.PERMISSION_ROLES_MANAGE, Description: "Create and edit roles and grant permissions to them"},
+}
+
+type (
+	// Role groups the permissions granted to the users carrying its code in their
+	// role field
+	Role struct {
+		ID          primitive.ObjectID `bson:"_id"`
+		Code        int8               `bson:"code"`
+		Name        string             `bson:"name"`
+		Description string             `bson:"description"`
+		Permissions []string           `bson:"permissions"`
+		CreatedAt   int64              `bson:"created_at"`
+		UpdatedAt   int64              `bson:"updated_at"`
+	}
+
+	UpdateRole struct {
+		ID          primitive.ObjectID `bson:"_id"`
+		Name        string             `bson:"name,omitempty"`
+		Description string             `bson:"description,omitempty"`
+		UpdatedAt   int64              `bson:"updated_at,omitempty"`
+	}
+
+	// RolePermissionRequest grants a permission to a role or revokes it
+	RolePermissionRequest struct {
+		ID         string
+		Permission string
+	}
+
+	Permission struct {
+		ID          primitive.ObjectID `bson:"_id"`
+		Name        string             `bson:"name"`
+		Description string             `bson:"description"`
+		CreatedAt   int64              `bson:"created_at"`
+	}
+)
+
+// HasPermission reports whether the role is granted permission
+func (r Role) HasPermission(permission string) bool {
+	return contains(r.Permissions, permission)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	SECURITY_EVENT_COLLECTION string = "security_events"
+
+	SECURITY_EVENT_REFRESH_TOKEN_REUSE string = "refresh_token_reuse"
+)
+
+type (
+	// SecurityEvent is an audit record of something suspicious happening to an account
+	SecurityEvent struct {
+		ID        primitive.ObjectID `bson:"_id"`
+		Type      string             `bson:"type"`
+		UserID    string             `bson:"user_id"`
+		ClientID  string             `bson:"client_id,omitempty"`
+		FamilyID  string             `bson:"family_id,omitempty"`
+		TokenID   string             `bson:"token_id,omitempty"`
+		IP        string             `bson:"ip"`
+		CreatedAt int64              `bson:"created_at"`
+	}
+)
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const SESSION_COLLECTION string = "sessions"
+
+type (
+	// Session is one sign in of a user, its id is the sid claim of the access
+	// and refresh tokens issued for it. ExpiresAt moves with every refresh.
+	Session struct {
+		ID         primitive.ObjectID `bson:"_id"`
+		UserID     string             `bson:"user_id"`
+		ClientID   string             `bson:"client_id,omitempty"`
+		UserAgent  string             `bson:"user_agent"`
+		IP         string             `bson:"ip"`
+		CreatedAt  int64              `bson:"created_at"`
+		LastUsedAt int64              `bson:"last_used_at"`
+		ExpiresAt  int64              `bson:"expires_at"`
+		RevokedAt  int64              `bson:"revoked_at"`
+	}
+
+	SessionResponse struct {
+		Session
+		Current bool
+	}
+)
@@ -14,24 +14,58 @@ const (
 	USER_COLLECTION string = "users"
 )
 
+// ROLE_NAMES are the names the built-in roles go by in config, they are seeded
+// into the roles collection on start
+var ROLE_NAMES = map[UserRole]string{
+	ADMIN:     "admin",
+	CUSTOMER:  "customer",
+	COMMITTEE: "committee",
+}
+
+// PORTAL_ADMIN, PORTAL_CUSTOMER and PORTAL_COMMITTEE are the portals the per
+// role login RPCs and magic links sign in to, they must be keys of the portals
+// config
+const (
+	PORTAL_ADMIN     string = "admin"
+	PORTAL_CUSTOMER  string = "customer"
+	PORTAL_COMMITTEE string = "committee"
+)
+
 type (
 	// User
 	User struct {
-		ID        primitive.ObjectID `bson:"_id"`
-		Name      string             `bson:"name"`
-		Role      int8               `bson:"role"`
-		Email     string             `bson:"email"`
-		Password  string             `bson:"password"`
-		IsActive  bool               `bson:"is_active"`
-		Note      string             `bson:"note"`
-		CreatedAt int64              `bson:"created_at"`
-		UpdatedAt int64              `bson:"updated_at"`
-		DeletedAt int64              `bson:"deleted_at"`
+		ID            primitive.ObjectID `bson:"_id"`
+		Name          string             `bson:"name"`
+		Role          int8               `bson:"role"`
+		Email         string             `bson:"email"`
+		EmailVerified bool               `bson:"email_verified"`
+		Password      string             `bson:"password"`
+		TOTPSecret    string             `bson:"totp_secret"`
+		MFAEnabled    bool               `bson:"mfa_enabled"`
+		RecoveryCodes []string           `bson:"recovery_codes"`
+		IsActive      bool               `bson:"is_active"`
+		Note          string             `bson:"note"`
+		TokenEpoch    int64              `bson:"token_epoch"`
+		CreatedAt     int64              `bson:"created_at"`
+		UpdatedAt     int64              `bson:"updated_at"`
+		DeletedAt     int64              `bson:"deleted_at"`
 	}
 
 	AuthResponse struct {
 		AccessToken  string
 		RefreshToken string
+		IDToken      string
+		ExpiresIn    int64
+		Scope        string
+		MFARequired  bool
+		MFAToken     string
+	}
+
+	// LoginRequest signs a user in to a portal, which the tokens are issued for
+	LoginRequest struct {
+		Portal   string
+		Email    string
+		Password string
 	}
 
 	RefreshTokenRequest struct {
@@ -47,6 +81,7 @@ type (
 	UpdateUser struct {
 		ID        primitive.ObjectID `bson:"_id"`
 		Name      string             `bson:"name,omitempty"`
+		Role      int8               `bson:"role,omitempty"`
 		IsActive  bool               `bson:"is_active"`
 		Note      string             `bson:"note,omitempty"`
 		UpdatedAt int64              `bson:"updated_at,omitempty"`
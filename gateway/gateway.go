@@ -31,20 +31,27 @@ type Gateway struct {
 	MaxHeaderBytes int
 	ReadTimeout    time.Duration
 	WriteTimeout   time.Duration
+	handlers       map[string]http.Handler
 }
 
 func NewGateway(addr string, opts ...runtime.ServeMuxOption) *Gateway {
+	json := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			EmitUnpopulated: true,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
+
 	opts = append(opts,
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
-			Marshaler: &runtime.JSONPb{
-				MarshalOptions: protojson.MarshalOptions{
-					EmitUnpopulated: true,
-				},
-				UnmarshalOptions: protojson.UnmarshalOptions{
-					DiscardUnknown: true,
-				},
-			},
+			Marshaler: json,
 		}),
+		runtime.WithMarshalerOption(MIMEFormURLEncoded, formMarshaler{
+			Marshaler: json,
+		}),
+		runtime.WithForwardResponseOption(redirect),
 	)
 	gwMux := runtime.NewServeMux(opts...)
 
@@ -54,9 +61,16 @@ func NewGateway(addr string, opts ...runtime.ServeMuxOption) *Gateway {
 		MaxHeaderBytes: MaxHeaderBytes,
 		ReadTimeout:    ReadTimeOut,
 		WriteTimeout:   WriteTimeOut,
+		handlers:       make(map[string]http.Handler),
 	}
 }
 
+// Handle registers a plain HTTP handler served next to the gRPC gateway routes,
+// e.g. well-known discovery documents
+func (gw *Gateway) Handle(pattern string, handler http.Handler) {
+	gw.handlers[pattern] = handler
+}
+
 func (gw *Gateway) swaggerUIHandler() (http.Handler, error) {
 	err := mime.AddExtensionType(".svg", "image/svg+xml")
 	if err != nil {
@@ -81,6 +95,10 @@ func (gw *Gateway) Run(ctx context.Context, cfg *bootstrap.Config) error {
 	mux.Handle("/static/", http.StripPrefix("/static", fileServer))
 	mux.Handle("/", sw)
 
+	for pattern, handler := range gw.handlers {
+		mux.Handle(pattern, handler)
+	}
+
 	gwServer := &http.Server{
 		Addr: fmt.Sprintf(":%v", gw.Addr),
 		Handler: middleware.CORS(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
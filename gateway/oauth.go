@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/proto"
+)
+
+const MIMEFormURLEncoded = "application/x-www-form-urlencoded"
+
+// formMarshaler reads form encoded bodies, which is how OAuth clients post to
+// /api/v1/token and /api/v1/introspect. Answers stay JSON.
+type formMarshaler struct {
+	runtime.Marshaler
+}
+
+func (m formMarshaler) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("can't decode a form into %T", v)
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	return runtime.PopulateQueryParameters(msg, values, utilities.NewDoubleArray(nil))
+}
+
+func (m formMarshaler) NewDecoder(r io.Reader) runtime.Decoder {
+	return runtime.DecoderFunc(func(v interface{}) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+
+		return m.Unmarshal(data, v)
+	})
+}
+
+// redirector is a response sending the browser on, like the authorization
+// response carrying the code back to the client
+type redirector interface {
+	GetRedirectTo() string
+}
+
+// redirect answers responses with a redirect target with a 302 to it
+func redirect(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	r, ok := resp.(redirector)
+	if !ok || r.GetRedirectTo() == "" {
+		return nil
+	}
+
+	w.Header().Set("Location", r.GetRedirectTo())
+	w.WriteHeader(http.StatusFound)
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/gateway"
+	"github.com/digisata/auth-service/stubs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type oauthServer struct {
+	stubs.UnimplementedAuthServiceServer
+	token *stubs.TokenRequest
+}
+
+func (s *oauthServer) Token(ctx context.Context, req *stubs.TokenRequest) (*stubs.TokenResponse, error) {
+	s.token = req
+
+	return &stubs.TokenResponse{AccessToken: "access", TokenType: "Bearer"}, nil
+}
+
+func (s *oauthServer) Authorize(ctx context.Context, req *stubs.AuthorizeRequest) (*stubs.AuthorizeResponse, error) {
+	return &stubs.AuthorizeResponse{
+		Code:       "code",
+		State:      req.State,
+		RedirectTo: req.RedirectUri + "?code=code&state=" + req.State,
+	}, nil
+}
+
+func newTestGateway(t *testing.T) (*gateway.Gateway, *oauthServer) {
+	gw := gateway.NewGateway("0")
+	server := &oauthServer{}
+	require.NoError(t, stubs.RegisterAuthServiceHandlerServer(context.Background(), gw.ServeMux, server))
+
+	return gw, server
+}
+
+func TestTokenAcceptsForms(t *testing.T) {
+	gw, server := newTestGateway(t)
+
+	body := "grant_type=authorization_code&code=abc&redirect_uri=https%3A%2F%2Fapp.example.com%2Fcallback&code_verifier=verifier"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/token", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	gw.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "authorization_code", server.token.GrantType)
+	assert.Equal(t, "abc", server.token.Code)
+	assert.Equal(t, "https://app.example.com/callback", server.token.RedirectUri)
+	assert.Equal(t, "verifier", server.token.CodeVerifier)
+
+	// The answer stays JSON
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "access", res["access_token"])
+
+	// JSON bodies keep working
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/token", strings.NewReader(`{"grant_type":"client_credentials"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+
+	gw.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "client_credentials", server.token.GrantType)
+}
+
+func TestAuthorizeRedirects(t *testing.T) {
+	gw, _ := newTestGateway(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/authorize?redirect_uri=https%3A%2F%2Fapp.example.com%2Fcallback&state=xyz", nil)
+	rec := httptest.NewRecorder()
+
+	gw.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://app.example.com/callback?code=code&state=xyz", rec.Header().Get("Location"))
+}
@@ -11,12 +11,22 @@ import (
 	"github.com/digisata/auth-service/controller"
 	"github.com/digisata/auth-service/domain"
 	"github.com/digisata/auth-service/gateway"
+	"github.com/digisata/auth-service/pkg/cache"
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/encryption"
 	"github.com/digisata/auth-service/pkg/grpcclient"
 	"github.com/digisata/auth-service/pkg/grpcserver"
 	"github.com/digisata/auth-service/pkg/interceptors"
 	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/mailer"
+	"github.com/digisata/auth-service/pkg/oidc"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/ratelimit"
+	"github.com/digisata/auth-service/pkg/rbac"
 	memcachedRepo "github.com/digisata/auth-service/repository/memcached"
+	memoryRepo "github.com/digisata/auth-service/repository/memory"
 	mongoRepo "github.com/digisata/auth-service/repository/mongo"
+	redisRepo "github.com/digisata/auth-service/repository/redis"
 	"github.com/digisata/auth-service/stubs"
 	"github.com/digisata/auth-service/usecase"
 	"go.uber.org/zap"
@@ -38,28 +48,111 @@ func main() {
 
 	cfg := app.Cfg
 
-	jwt := jwtio.NewJSONWebToken(&cfg.Jwt, app.MemcachedDB)
+	var (
+		cacheRepository usecase.CacheRepository
+		tokenStore      jwtio.TokenStore
+	)
+
+	switch cfg.Cache.Driver {
+	case cache.DRIVER_REDIS:
+		cacheRepository = redisRepo.NewCacheRepository(app.RedisDB)
+		tokenStore = jwtio.NewRedisTokenStore(app.RedisDB)
+	case cache.DRIVER_MEMORY:
+		cacheRepository = memoryRepo.NewCacheRepository(app.MemoryCache)
+		tokenStore = jwtio.NewMemoryTokenStore(app.MemoryCache)
+	default:
+		cacheRepository = memcachedRepo.NewCacheRepository(app.MemcachedDB)
+		tokenStore = jwtio.NewMemcachedTokenStore(app.MemcachedDB)
+	}
+
+	db := app.Mongo.Database(cfg.Mongo.DBName)
+	defer app.CloseDBConnection()
+
+	// Token generations live on the user document, the token store only caches them
+	userRepository := mongoRepo.NewUserRepository(db, domain.USER_COLLECTION)
+
+	jwt, err := jwtio.NewJSONWebToken(&cfg.Jwt, tokenStore, usecase.NewTokenEpochSource(userRepository))
+	if err != nil {
+		panic(err)
+	}
 
 	logger, _ := zap.NewProduction()
 	defer logger.Sync() // flushes buffer, if any
 
 	sugar := logger.Sugar()
 
-	// Dependencies injection
-	db := app.Mongo.Database(cfg.Mongo.DBName)
-	defer app.CloseDBConnection()
+	bootstrap.WatchConfig(func(newCfg *bootstrap.Config) {
+		err := jwt.Reload(&newCfg.Jwt)
+		if err != nil {
+			sugar.Errorw(constants.ERROR,
+				"message", "failed to reload signing keys",
+				"error", err.Error(),
+			)
+			return
+		}
+
+		accessKeyID, refreshKeyID := jwt.ActiveKeyIDs()
+		sugar.Infow(constants.INFO,
+			"message", "signing keys reloaded",
+			"access_key_id", accessKeyID,
+			"refresh_key_id", refreshKeyID,
+		)
+	})
+
+	cipher, err := encryption.NewCipher(cfg.MFA.EncryptionKey)
+	if err != nil {
+		panic(err)
+	}
 
-	userRepository := mongoRepo.NewUserRepository(db, domain.USER_COLLECTION)
+	mail, err := mailer.NewMailer(cfg.Mailer)
+	if err != nil {
+		panic(err)
+	}
+
+	passwordPolicy, err := password.NewPolicy(cfg.Password)
+	if err != nil {
+		panic(err)
+	}
+
+	passwordHasher, err := password.NewHasher(cfg.Password.Hashing)
+	if err != nil {
+		panic(err)
+	}
+
+	// Dependencies injection
 	profileRepository := mongoRepo.NewProfileRepository(db, domain.USER_COLLECTION)
-	cacheRepository := memcachedRepo.NewCacheRepository(app.MemcachedDB)
+	clientRepository := mongoRepo.NewClientRepository(db, domain.CLIENT_COLLECTION)
+	passwordHistoryRepository := mongoRepo.NewPasswordHistoryRepository(db, domain.PASSWORD_HISTORY_COLLECTION)
+	securityEventRepository := mongoRepo.NewSecurityEventRepository(db, domain.SECURITY_EVENT_COLLECTION)
+	sessionRepository := mongoRepo.NewSessionRepository(db, domain.SESSION_COLLECTION)
+	roleRepository := mongoRepo.NewRoleRepository(db, domain.ROLE_COLLECTION)
+	permissionRepository := mongoRepo.NewPermissionRepository(db, domain.PERMISSION_COLLECTION)
 	timeout := time.Duration(cfg.ContextTimeout) * time.Second
+
+	roleResolver := rbac.NewResolver(usecase.NewRoleLoader(roleRepository), cfg.Roles.CacheTTL(), nil)
+	roleUsecase := usecase.NewRoleUsecase(cfg, roleRepository, permissionRepository, roleResolver, timeout)
+	err = roleUsecase.Seed(ctx)
+	if err != nil {
+		panic(err)
+	}
+
 	authController := &controller.AuthController{
-		UserUsecase:    usecase.NewUserUsecase(jwt, cfg, userRepository, cacheRepository, timeout),
-		ProfileUsecase: usecase.NewProfileUsecase(jwt, cfg, profileRepository, cacheRepository, timeout),
+		UserUsecase:    usecase.NewUserUsecase(jwt, cfg, userRepository, passwordHistoryRepository, sessionRepository, securityEventRepository, clientRepository, cacheRepository, roleResolver, passwordPolicy, passwordHasher, sugar, timeout),
+		ProfileUsecase: usecase.NewProfileUsecase(jwt, cfg, profileRepository, passwordHistoryRepository, sessionRepository, cacheRepository, roleResolver, passwordPolicy, passwordHasher, timeout),
+		AccountUsecase: usecase.NewAccountUsecase(jwt, cfg, userRepository, passwordHistoryRepository, sessionRepository, cacheRepository, mail, roleResolver, passwordPolicy, passwordHasher, sugar, timeout),
+		MFAUsecase:     usecase.NewMFAUsecase(jwt, cfg, userRepository, sessionRepository, cacheRepository, cipher, timeout),
+		ClientUsecase:  usecase.NewClientUsecase(clientRepository, timeout),
+		RoleUsecase:    roleUsecase,
+		OAuthUsecase:   usecase.NewOAuthUsecase(jwt, cfg, userRepository, clientRepository, sessionRepository, cacheRepository, timeout),
 	}
 
 	// Setup GRPC server
-	im := interceptors.NewInterceptorManager(jwt, sugar)
+	limiter, err := ratelimit.NewLimiter(cfg.RateLimit, app.MemcachedDB, app.RedisDB)
+	if err != nil {
+		panic(err)
+	}
+
+	im := interceptors.NewInterceptorManager(jwt, limiter, cfg.RateLimit, roleResolver, sugar)
 	altsTC := alts.NewServerCreds(alts.DefaultServerOptions())
 	grpcServer, err := grpcserver.NewGrpcServer(cfg.GrpcServer, im, sugar, grpc.Creds(altsTC))
 	if err != nil {
@@ -85,6 +178,8 @@ func main() {
 
 	// Setup gateway mux
 	gatewayServer := gateway.NewGateway(cfg.Port)
+	gatewayServer.Handle("/.well-known/jwks.json", jwt.JWKSHandler())
+	gatewayServer.Handle("/.well-known/openid-configuration", oidc.NewDiscoveryHandler(cfg.Jwt.Issuer, jwt.SigningAlgorithms))
 	err = stubs.RegisterAuthServiceHandler(ctx, gatewayServer.ServeMux, grpcClientConn)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,30 @@
+// Package cache selects where cached values and the state of issued tokens live,
+// and holds the in process cache used by the memory driver
+package cache
+
+import "time"
+
+const (
+	DRIVER_MEMCACHED string = "memcached"
+	DRIVER_REDIS     string = "redis"
+	DRIVER_MEMORY    string = "memory"
+
+	DEFAULT_EVICTION_INTERVAL time.Duration = time.Minute
+)
+
+type Config struct {
+	Driver string `mapstructure:"DRIVER"`
+	// MaxEntries bounds the memory cache, past it the least recently used entries
+	// are evicted. 0 leaves it unbounded. Revocations are never evicted.
+	MaxEntries int `mapstructure:"MAX_ENTRIES"`
+	// EvictionIntervalSecond is how often the memory cache drops expired entries
+	EvictionIntervalSecond int `mapstructure:"EVICTION_INTERVAL_SECOND"`
+}
+
+func (cfg Config) EvictionInterval() time.Duration {
+	if cfg.EvictionIntervalSecond <= 0 {
+		return DEFAULT_EVICTION_INTERVAL
+	}
+
+	return time.Duration(cfg.EvictionIntervalSecond) * time.Second
+}
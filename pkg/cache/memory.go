@@ -0,0 +1,288 @@
+package cache
+
+import (
+	"container/list"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	memoryEntry struct {
+		key   string
+		value []byte
+		exp   int64
+	}
+
+	// Memory is a cache local to the process, for single instance deployments and
+	// tests. Entries expire at a unix time like memcached items, expired entries
+	// are never returned and are dropped in the background.
+	Memory struct {
+		mu         sync.Mutex
+		maxEntries int
+		entries    map[string]*list.Element
+		// recent orders entries from most to least recently used
+		recent *list.List
+		// pinned holds the entries stored with Pin, which only expire
+		pinned map[string]*memoryEntry
+		now    func() time.Time
+		stop   chan struct{}
+		once   sync.Once
+	}
+)
+
+// NewMemory starts a memory cache and its eviction. now is the clock expiry is
+// checked against, nil uses the wall clock.
+func NewMemory(cfg Config, now func() time.Time) *Memory {
+	if now == nil {
+		now = time.Now
+	}
+
+	m := &Memory{
+		maxEntries: cfg.MaxEntries,
+		entries:    make(map[string]*list.Element),
+		recent:     list.New(),
+		pinned:     make(map[string]*memoryEntry),
+		now:        now,
+		stop:       make(chan struct{}),
+	}
+
+	go m.evictEvery(cfg.EvictionInterval())
+
+	return m
+}
+
+func (m *Memory) evictEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.EvictExpired()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+// Close stops the background eviction
+func (m *Memory) Close() {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+}
+
+func (m *Memory) expired(e *memoryEntry) bool {
+	return e.exp != 0 && m.now().Unix() >= e.exp
+}
+
+// lookup returns the live entry of key, dropping it if it expired
+func (m *Memory) lookup(key string) (*list.Element, bool) {
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if m.expired(el.Value.(*memoryEntry)) {
+		m.remove(el)
+		return nil, false
+	}
+
+	return el, true
+}
+
+func (m *Memory) remove(el *list.Element) {
+	m.recent.Remove(el)
+	delete(m.entries, el.Value.(*memoryEntry).key)
+}
+
+func (m *Memory) set(key string, value []byte, exp int64) {
+	delete(m.pinned, key)
+
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*memoryEntry)
+		e.value = value
+		e.exp = exp
+		m.recent.MoveToFront(el)
+
+		return
+	}
+
+	m.entries[key] = m.recent.PushFront(&memoryEntry{key: key, value: value, exp: exp})
+
+	if m.maxEntries > 0 && m.recent.Len() > m.maxEntries {
+		m.remove(m.recent.Back())
+	}
+}
+
+// Set stores value under key until the unix time exp, 0 keeps it until it is
+// deleted or evicted. A value that would have expired already is removed instead.
+func (m *Memory) Set(key string, value []byte, exp int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if exp != 0 && m.now().Unix() >= exp {
+		if el, ok := m.entries[key]; ok {
+			m.remove(el)
+		}
+
+		return
+	}
+
+	m.set(key, value, exp)
+}
+
+// Pin stores value under key until the unix time exp like Set, but the entry is
+// never evicted to make room for others. It is meant for the few entries that
+// must not be lost before they expire, e.g. revocations.
+func (m *Memory) Pin(key string, value []byte, exp int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.entries[key]; ok {
+		m.remove(el)
+	}
+
+	if exp != 0 && m.now().Unix() >= exp {
+		delete(m.pinned, key)
+		return
+	}
+
+	m.pinned[key] = &memoryEntry{key: key, value: value, exp: exp}
+}
+
+// lookupPinned returns the live pinned entry of key, dropping it if it expired
+func (m *Memory) lookupPinned(key string) (*memoryEntry, bool) {
+	e, ok := m.pinned[key]
+	if !ok {
+		return nil, false
+	}
+
+	if m.expired(e) {
+		delete(m.pinned, key)
+		return nil, false
+	}
+
+	return e, true
+}
+
+// Get returns the value of key and whether it is cached
+func (m *Memory) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.lookupPinned(key); ok {
+		return e.value, true
+	}
+
+	el, ok := m.lookup(key)
+	if !ok {
+		return nil, false
+	}
+
+	m.recent.MoveToFront(el)
+
+	return el.Value.(*memoryEntry).value, true
+}
+
+// Delete removes key and reports whether it was cached
+func (m *Memory) Delete(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.lookupPinned(key); ok {
+		delete(m.pinned, key)
+		return true
+	}
+
+	el, ok := m.lookup(key)
+	if !ok {
+		return false
+	}
+
+	m.remove(el)
+
+	return true
+}
+
+// Increment adds one to the counter at key and returns the new value. A missing
+// counter is created to expire at the unix time exp, which later increments
+// don't extend.
+func (m *Memory) Increment(key string, exp int64) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.lookup(key)
+	if !ok {
+		m.set(key, []byte("1"), exp)
+		return 1, nil
+	}
+
+	e := el.Value.(*memoryEntry)
+
+	value, err := strconv.ParseInt(string(e.value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	value++
+	e.value = []byte(strconv.FormatInt(value, 10))
+	m.recent.MoveToFront(el)
+
+	return value, nil
+}
+
+// SetMax stores the counter value under key until the unix time exp, unless the
+// counter already there is at least as high
+func (m *Memory) SetMax(key string, value int64, exp int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.lookup(key)
+	if ok {
+		current, err := strconv.ParseInt(string(el.Value.(*memoryEntry).value), 10, 64)
+		if err != nil {
+			return err
+		}
+
+		if current >= value {
+			return nil
+		}
+	}
+
+	m.set(key, []byte(strconv.FormatInt(value, 10)), exp)
+
+	return nil
+}
+
+// EvictExpired drops every expired entry and returns how many there were
+func (m *Memory) EvictExpired() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	evicted := 0
+	for _, el := range m.entries {
+		if m.expired(el.Value.(*memoryEntry)) {
+			m.remove(el)
+			evicted++
+		}
+	}
+
+	for key, e := range m.pinned {
+		if m.expired(e) {
+			delete(m.pinned, key)
+			evicted++
+		}
+	}
+
+	return evicted
+}
+
+// Len returns the number of entries, expired ones not evicted yet included
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.recent.Len() + len(m.pinned)
+}
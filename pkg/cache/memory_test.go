@@ -0,0 +1,132 @@
+package cache_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	memory := cache.NewMemory(cache.Config{}, func() time.Time { return now })
+	defer memory.Close()
+
+	memory.Set("a", []byte("1"), now.Add(time.Minute).Unix())
+	memory.Set("b", []byte("2"), 0)
+
+	// A value that expired already is not stored
+	memory.Set("c", []byte("3"), now.Unix())
+	_, ok := memory.Get("c")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+
+	_, ok = memory.Get("a")
+	assert.False(t, ok)
+
+	value, ok := memory.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("2"), value)
+}
+
+func TestMemoryEviction(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var mu sync.Mutex
+	memory := cache.NewMemory(cache.Config{EvictionIntervalSecond: 1}, func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return now
+	})
+	defer memory.Close()
+
+	for i := 0; i < 3; i++ {
+		memory.Set(fmt.Sprint(i), []byte("1"), now.Add(time.Minute).Unix())
+	}
+	memory.Set("forever", []byte("1"), 0)
+
+	mu.Lock()
+	now = now.Add(time.Minute)
+	mu.Unlock()
+
+	// Expired entries go without being looked up
+	assert.Eventually(t, func() bool {
+		return memory.Len() == 1
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestMemoryLRU(t *testing.T) {
+	memory := cache.NewMemory(cache.Config{MaxEntries: 2}, nil)
+	defer memory.Close()
+
+	memory.Set("a", []byte("1"), 0)
+	memory.Set("b", []byte("2"), 0)
+
+	// Reading a makes b the least recently used
+	_, ok := memory.Get("a")
+	require.True(t, ok)
+
+	memory.Set("c", []byte("3"), 0)
+
+	_, ok = memory.Get("b")
+	assert.False(t, ok)
+	_, ok = memory.Get("a")
+	assert.True(t, ok)
+	_, ok = memory.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, memory.Len())
+}
+
+func TestMemoryPin(t *testing.T) {
+	now := time.Now()
+	memory := cache.NewMemory(cache.Config{MaxEntries: 2}, func() time.Time { return now })
+	defer memory.Close()
+
+	memory.Pin("pinned", []byte("1"), now.Add(time.Minute).Unix())
+
+	// Pinned entries neither count towards the limit nor get evicted for it
+	for _, key := range []string{"a", "b", "c", "d"} {
+		memory.Set(key, []byte("1"), 0)
+	}
+
+	_, ok := memory.Get("pinned")
+	assert.True(t, ok)
+	_, ok = memory.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 3, memory.Len())
+
+	// They still expire
+	now = now.Add(time.Minute)
+
+	_, ok = memory.Get("pinned")
+	assert.False(t, ok)
+
+	memory.Pin("pinned", []byte("1"), 0)
+	assert.True(t, memory.Delete("pinned"))
+	assert.False(t, memory.Delete("pinned"))
+}
+
+func TestMemoryConcurrentIncrement(t *testing.T) {
+	memory := cache.NewMemory(cache.Config{}, nil)
+	defer memory.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := memory.Increment("counter", 0)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	value, ok := memory.Get("counter")
+	require.True(t, ok)
+	assert.Equal(t, "50", string(value))
+}
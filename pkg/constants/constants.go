@@ -1,18 +1,29 @@
 package constants
 
-type UserRole int8
-
 const (
-	ADMIN UserRole = iota + 1
-	CUSTOMER
-	COMMITTEE
-
 	PATH string = "/proto.AuthService/"
 
+	SCOPE_USERS_READ string = "users.read"
+
+	// The PERMISSION_ constants are what roles are granted to call the methods of
+	// this service
+	PERMISSION_USERS_READ     string = "users.read"
+	PERMISSION_USERS_WRITE    string = "users.write"
+	PERMISSION_CLIENTS_MANAGE string = "clients.manage"
+	PERMISSION_ROLES_MANAGE   string = "roles.manage"
+
 	TOKEN_EXPIRED             string = "token has been expired"
 	REFRESH_TOKEN_EXPIRED     string = "refresh token has been expired"
+	REFRESH_TOKEN_REUSED      string = "refresh token has already been used, please login again"
+	TOKEN_REVOKED             string = "token has been revoked, please login again"
 	FAILED_TO_EXTRACT         string = "failed to extract jwt payload"
 	UNEXPECTED_SIGNING_METHOD string = "unexpected signing method: %v"
+	UNKNOWN_SIGNING_KEY       string = "unknown signing key"
+
+	// ERROR_DOMAIN and the REASON_ constants fill the ErrorInfo details of errors
+	// clients are expected to act on
+	ERROR_DOMAIN         string = "auth-service"
+	REASON_TOKEN_REVOKED string = "TOKEN_REVOKED"
 
 	INFO  string = "INFO"
 	WARN  string = "WARN"
@@ -8,6 +8,7 @@ import (
 
 	"github.com/digisata/auth-service/pkg/constants"
 	"github.com/digisata/auth-service/pkg/interceptors"
+	"github.com/digisata/auth-service/pkg/utils"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -26,6 +27,9 @@ type (
 		Port    string `mapstructure:"PORT"`
 		Network string `mapstructure:"NETWORK"`
 		Tls     bool   `mapstructure:"TLS"`
+		// TrustedProxies may forward the address of their caller as X-Forwarded-For,
+		// on top of the gateway which always may
+		TrustedProxies []string `mapstructure:"TRUSTED_PROXIES"`
 	}
 
 	GrpcServer struct {
@@ -56,6 +60,11 @@ func NewGrpcServer(cfg Config, im interceptors.InterceptorManager, logger *zap.S
 		opts = append(opts, grpc.Creds(creds))
 	}
 
+	trustedProxies, err := utils.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
 	opts = append(
 		opts,
 		grpc.KeepaliveParams(keepalive.ServerParameters{
@@ -69,8 +78,10 @@ func NewGrpcServer(cfg Config, im interceptors.InterceptorManager, logger *zap.S
 			grpcPrometheus.UnaryServerInterceptor,
 			grpcRecovery.UnaryServerInterceptor(),
 			otelgrpc.UnaryServerInterceptor(),
+			interceptors.NewClientIPInterceptor(trustedProxies),
 			im.Logger,
 			im.AuthenticationInterceptor,
+			im.RateLimitInterceptor,
 			im.AuthorizationInterceptor,
 		)),
 	)
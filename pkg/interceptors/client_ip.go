@@ -0,0 +1,24 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/digisata/auth-service/pkg/utils"
+	"google.golang.org/grpc"
+)
+
+// NewClientIPInterceptor resolves the address of the caller once per request,
+// so rate limits, lockouts and sessions all see the same one. X-Forwarded-For is
+// only believed from trusted proxies.
+func NewClientIPInterceptor(trusted utils.TrustedProxies) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx = utils.WithClientIP(ctx, utils.ResolveClientIP(ctx, trusted))
+
+		return handler(ctx, req)
+	}
+}
@@ -2,9 +2,11 @@ package interceptors
 
 import (
 	"context"
+	"strings"
 
 	"github.com/digisata/auth-service/pkg/constants"
 	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/ratelimit"
 	"github.com/digisata/auth-service/pkg/tracing"
 	"github.com/golang-jwt/jwt/v4"
 	"go.uber.org/zap"
@@ -41,23 +43,42 @@ type InterceptorManager interface {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error)
+	RateLimitInterceptor(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error)
+}
+
+// RoleResolver tells whether a role grants a permission
+type RoleResolver interface {
+	HasPermission(ctx context.Context, role int8, permission string) (bool, error)
 }
 
 // InterceptorManager struct
 type interceptorManager struct {
-	logger           *zap.SugaredLogger
-	jwtManager       *jwtio.JSONWebToken
-	protectedMethods map[string]bool
-	allowedRoles     map[string][]int8
+	logger              *zap.SugaredLogger
+	jwtManager          *jwtio.JSONWebToken
+	limiter             ratelimit.Limiter
+	rateLimit           ratelimit.Config
+	roles               RoleResolver
+	protectedMethods    map[string]bool
+	requiredPermissions map[string]string
+	allowedScopes       map[string]string
 }
 
 // NewInterceptorManager InterceptorManager constructor
-func NewInterceptorManager(jwtManager *jwtio.JSONWebToken, logger *zap.SugaredLogger) *interceptorManager {
+func NewInterceptorManager(jwtManager *jwtio.JSONWebToken, limiter ratelimit.Limiter, rateLimit ratelimit.Config, roles RoleResolver, logger *zap.SugaredLogger) *interceptorManager {
 	return &interceptorManager{
-		logger:           logger,
-		jwtManager:       jwtManager,
-		protectedMethods: protectedMethods(),
-		allowedRoles:     allowedRoles(),
+		logger:              logger,
+		jwtManager:          jwtManager,
+		limiter:             limiter,
+		rateLimit:           rateLimit,
+		roles:               roles,
+		protectedMethods:    protectedMethods(),
+		requiredPermissions: requiredPermissions(),
+		allowedScopes:       allowedScopes(),
 	}
 }
 
@@ -154,21 +175,28 @@ func (im interceptorManager) AuthorizationInterceptor(
 		return handler(ctx, req)
 	}
 
-	claims := ctx.Value("claims")
-	role := int8(claims.(jwt.MapClaims)["role"].(float64))
+	claims := ctx.Value("claims").(jwt.MapClaims)
 
-	roles, isAuthorizationNeeded := im.allowedRoles[info.FullMethod]
+	// Services have no role, they may only call the methods granted to a scope
+	if jwtio.IsService(claims) {
+		scope, isServiceAllowed := im.allowedScopes[info.FullMethod]
+		if !isServiceAllowed || !hasScope(claims, scope) {
+			return nil, status.Error(codes.Unauthenticated, "Not allowed to access this resource")
+		}
+
+		return handler(ctx, req)
+	}
+
+	permission, isAuthorizationNeeded := im.requiredPermissions[info.FullMethod]
 	if !isAuthorizationNeeded {
 		return handler(ctx, req)
 	}
 
-	isAuthorized := false
+	role := int8(claims["role"].(float64))
 
-	for _, val := range roles {
-		if role == val {
-			isAuthorized = true
-			break
-		}
+	isAuthorized, err := im.roles.HasPermission(ctx, role, permission)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	if !isAuthorized {
@@ -178,29 +206,101 @@ func (im interceptorManager) AuthorizationInterceptor(
 	return handler(ctx, req)
 }
 
+func hasScope(claims jwt.MapClaims, scope string) bool {
+	granted, _ := claims["scope"].(string)
+	for _, val := range strings.Fields(granted) {
+		if val == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
 func protectedMethods() map[string]bool {
 	return map[string]bool{
 		// User
-		constants.PATH + "CreateUser":  true,
-		constants.PATH + "GetAllUser":  true,
-		constants.PATH + "GetUserByID": true,
-		constants.PATH + "UpdateUser":  true,
-		constants.PATH + "DeleteUser":  true,
-		constants.PATH + "Logout":      true,
+		constants.PATH + "CreateUser":         true,
+		constants.PATH + "GetAllUser":         true,
+		constants.PATH + "GetUserByID":        true,
+		constants.PATH + "UpdateUser":         true,
+		constants.PATH + "DeleteUser":         true,
+		constants.PATH + "UnlockUser":         true,
+		constants.PATH + "ChangeUserPassword": true,
+		constants.PATH + "Logout":             true,
 
 		// Profile
-		constants.PATH + "GetProfileByID": true,
-		constants.PATH + "ChangePassword": true,
+		constants.PATH + "GetProfileByID":      true,
+		constants.PATH + "ChangePassword":      true,
+		constants.PATH + "ListMySessions":      true,
+		constants.PATH + "RevokeMySession":     true,
+		constants.PATH + "RevokeOtherSessions": true,
+
+		// Account
+		constants.PATH + "SendVerificationEmail": true,
+
+		// MFA
+		constants.PATH + "EnrollTOTP":              true,
+		constants.PATH + "ConfirmTOTP":             true,
+		constants.PATH + "DisableTOTP":             true,
+		constants.PATH + "RegenerateRecoveryCodes": true,
+
+		// Client
+		constants.PATH + "CreateClient":       true,
+		constants.PATH + "ListClients":        true,
+		constants.PATH + "UpdateClient":       true,
+		constants.PATH + "RotateClientSecret": true,
+		constants.PATH + "DeleteClient":       true,
+
+		// Role
+		constants.PATH + "CreateRole":       true,
+		constants.PATH + "ListRoles":        true,
+		constants.PATH + "UpdateRole":       true,
+		constants.PATH + "GrantPermission":  true,
+		constants.PATH + "RevokePermission": true,
+		constants.PATH + "ListPermissions":  true,
+
+		// OAuth
+		constants.PATH + "UserInfo":  true,
+		constants.PATH + "Authorize": true,
+	}
+}
+
+// requiredPermissions lists the methods that need a permission granted to the
+// role of the caller
+func requiredPermissions() map[string]string {
+	return map[string]string{
+		// User
+		constants.PATH + "CreateUser":         constants.PERMISSION_USERS_WRITE,
+		constants.PATH + "GetAllUser":         constants.PERMISSION_USERS_READ,
+		constants.PATH + "GetUserByID":        constants.PERMISSION_USERS_READ,
+		constants.PATH + "UpdateUser":         constants.PERMISSION_USERS_WRITE,
+		constants.PATH + "DeleteUser":         constants.PERMISSION_USERS_WRITE,
+		constants.PATH + "UnlockUser":         constants.PERMISSION_USERS_WRITE,
+		constants.PATH + "ChangeUserPassword": constants.PERMISSION_USERS_WRITE,
+
+		// Client
+		constants.PATH + "CreateClient":       constants.PERMISSION_CLIENTS_MANAGE,
+		constants.PATH + "ListClients":        constants.PERMISSION_CLIENTS_MANAGE,
+		constants.PATH + "UpdateClient":       constants.PERMISSION_CLIENTS_MANAGE,
+		constants.PATH + "RotateClientSecret": constants.PERMISSION_CLIENTS_MANAGE,
+		constants.PATH + "DeleteClient":       constants.PERMISSION_CLIENTS_MANAGE,
+
+		// Role
+		constants.PATH + "CreateRole":       constants.PERMISSION_ROLES_MANAGE,
+		constants.PATH + "ListRoles":        constants.PERMISSION_ROLES_MANAGE,
+		constants.PATH + "UpdateRole":       constants.PERMISSION_ROLES_MANAGE,
+		constants.PATH + "GrantPermission":  constants.PERMISSION_ROLES_MANAGE,
+		constants.PATH + "RevokePermission": constants.PERMISSION_ROLES_MANAGE,
+		constants.PATH + "ListPermissions":  constants.PERMISSION_ROLES_MANAGE,
 	}
 }
 
-func allowedRoles() map[string][]int8 {
-	return map[string][]int8{
+// allowedScopes lists the methods a service token may call and the scope it needs
+func allowedScopes() map[string]string {
+	return map[string]string{
 		// User
-		constants.PATH + "CreateUser":  {int8(constants.ADMIN)},
-		constants.PATH + "GetAllUser":  {int8(constants.ADMIN)},
-		constants.PATH + "GetUserByID": {int8(constants.ADMIN)},
-		constants.PATH + "UpdateUser":  {int8(constants.ADMIN)},
-		constants.PATH + "DeleteUser":  {int8(constants.ADMIN)},
+		constants.PATH + "GetAllUser":  constants.SCOPE_USERS_READ,
+		constants.PATH + "GetUserByID": constants.SCOPE_USERS_READ,
 	}
 }
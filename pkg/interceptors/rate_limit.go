@@ -0,0 +1,98 @@
+package interceptors
+
+import (
+	"context"
+	"math"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/ratelimit"
+	"github.com/digisata/auth-service/pkg/tracing"
+	"github.com/digisata/auth-service/pkg/utils"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RateLimitInterceptor runs after authentication, so policies keyed by user can
+// read the claims. A failing limiter backend lets the call through rather than
+// taking the service down with it.
+func (im interceptorManager) RateLimitInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, span := tracing.StartGrpcServerTracerSpan(ctx, "Interceptors.RateLimitInterceptor")
+	defer span.End()
+
+	method := path.Base(info.FullMethod)
+
+	policy := im.rateLimit.Policy(method)
+	if !policy.Enabled() {
+		return handler(ctx, req)
+	}
+
+	key := method + ":" + rateLimitSubject(ctx, policy)
+
+	allowed, retryAfter, err := im.limiter.Allow(key, policy, time.Now())
+	if err != nil {
+		im.logger.Errorw(constants.ERROR,
+			"method", info.FullMethod,
+			"message", "rate limiter unavailable",
+			"error", err.Error(),
+		)
+
+		return handler(ctx, req)
+	}
+
+	if !allowed {
+		return nil, rateLimitError(ctx, retryAfter)
+	}
+
+	return handler(ctx, req)
+}
+
+func rateLimitSubject(ctx context.Context, policy ratelimit.Policy) string {
+	if policy.Key == ratelimit.KEY_USER {
+		claims, ok := ctx.Value("claims").(jwt.MapClaims)
+		if ok {
+			if jwtio.IsService(claims) {
+				if sub, ok := claims["sub"].(string); ok {
+					return "client:" + sub
+				}
+			}
+
+			if id, ok := claims["id"].(string); ok {
+				return "user:" + id
+			}
+		}
+	}
+
+	return "ip:" + utils.ClientIP(ctx)
+}
+
+// rateLimitError carries the delay as RetryInfo for gRPC clients, and as a
+// retry-after header which the gateway passes on to HTTP clients
+func rateLimitError(ctx context.Context, retryAfter time.Duration) error {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.FormatInt(seconds, 10)))
+
+	st := status.New(codes.ResourceExhausted, "Too many requests, please try again later")
+
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
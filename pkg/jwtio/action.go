@@ -0,0 +1,76 @@
+package jwtio
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ActionClaims are the claims of single purpose tokens that end up in emailed
+// links. They are signed with the refresh keyring since only this service ever
+// reads them, and carry a jti so the caller can make them single use.
+type ActionClaims struct {
+	Purpose string `json:"purpose"`
+	Email   string `json:"email,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func newJTI() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// CreateActionToken signs a token for purpose on behalf of a user and returns it
+// with its jti
+func (j JSONWebToken) CreateActionToken(purpose, userID, email string, now time.Time, expiry time.Duration) (string, string, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return "", "", status.Error(codes.Internal, err.Error())
+	}
+
+	claims := &ActionClaims{
+		Purpose: purpose,
+		Email:   email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    j.cfg.Issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
+		},
+	}
+	t, err := sign(claims, j.keys.Load().refresh.signer())
+	if err != nil {
+		return "", "", status.Error(codes.Internal, err.Error())
+	}
+
+	return t, jti, nil
+}
+
+// VerifyActionToken checks the signature, expiry and purpose of an action token
+func (j JSONWebToken) VerifyActionToken(token, purpose string) (*ActionClaims, error) {
+	claims := &ActionClaims{}
+
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return j.validateToken(token, j.keys.Load().refresh)
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	if claims.Purpose != purpose || claims.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,102 @@
+package jwtio
+
+import (
+	"errors"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTokenReused is returned for a refresh token that was already rotated
+var ErrTokenReused = errors.New("refresh token reused")
+
+// TokenFamily places a refresh token in the chain of rotations it came from.
+// An empty ID starts a new family, Parent is the jti of the rotated token.
+type TokenFamily struct {
+	ID     string
+	Parent string
+}
+
+// RevokeTokenFamily retires every refresh token of a family until the given
+// time, by which the newest of them has expired anyway
+func (j JSONWebToken) RevokeTokenFamily(familyID string, until time.Time) error {
+	err := j.store.Mark(MARK_REVOKED_FAMILY, familyID, until)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// ConsumeRefreshToken retires the refresh token of parsed claims for its rotation
+// and returns the family its replacement joins. Whoever retires the token first
+// owns the rotation, a token that was rotated before gets ErrTokenReused.
+func (j JSONWebToken) ConsumeRefreshToken(claims jwt.MapClaims) (TokenFamily, error) {
+	var family TokenFamily
+
+	jti, _ := claims["jti"].(string)
+	familyID, _ := claims["fid"].(string)
+	if jti == "" {
+		return family, status.Error(codes.Unauthenticated, constants.REFRESH_TOKEN_EXPIRED)
+	}
+
+	err := j.store.Delete(jti)
+	if err != nil {
+		if !errors.Is(err, ErrTokenNotFound) {
+			return family, status.Error(codes.Internal, err.Error())
+		}
+
+		rotated, err := j.store.Marked(MARK_ROTATED, jti)
+		if err != nil {
+			return family, status.Error(codes.Internal, err.Error())
+		}
+
+		if rotated {
+			return family, ErrTokenReused
+		}
+
+		return family, status.Error(codes.Unauthenticated, constants.REFRESH_TOKEN_EXPIRED)
+	}
+
+	// Tokens issued before families existed start a new one
+	if familyID == "" {
+		return family, nil
+	}
+
+	exp, _ := claims["exp"].(float64)
+
+	err = j.store.Mark(MARK_ROTATED, jti, time.Unix(int64(exp), 0))
+	if err != nil {
+		return family, status.Error(codes.Internal, err.Error())
+	}
+
+	family = TokenFamily{
+		ID:     familyID,
+		Parent: jti,
+	}
+
+	return family, nil
+}
+
+// checkFamilyRevoked rejects refresh tokens of a revoked family. Tokens issued
+// before families existed have none and are not checked.
+func (j JSONWebToken) checkFamilyRevoked(claims jwt.MapClaims, reason string) error {
+	familyID, _ := claims["fid"].(string)
+	if familyID == "" {
+		return nil
+	}
+
+	revoked, err := j.store.Marked(MARK_REVOKED_FAMILY, familyID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if revoked {
+		return status.Error(codes.Unauthenticated, reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package jwtio
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type (
+	// JWK is a public key in JSON Web Key format (RFC 7517)
+	JWK struct {
+		Kty string `json:"kty"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg,omitempty"`
+		Kid string `json:"kid,omitempty"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+
+	JWKSet struct {
+		Keys []JWK `json:"keys"`
+	}
+)
+
+// JWKS returns the public keys used to verify access tokens, including keys that
+// are not active yet or no longer sign, so verifiers can follow a rotation.
+// Symmetric keys are never published.
+func (j JSONWebToken) JWKS() (JWKSet, error) {
+	set := JWKSet{Keys: []JWK{}}
+	kr := j.keys.Load().access
+
+	for _, kid := range kr.order {
+		key := kr.keys[kid]
+		if key.isSymmetric() {
+			continue
+		}
+
+		jwk, err := publicKeyToJWK(key.verifyKey)
+		if err != nil {
+			return set, err
+		}
+
+		jwk.Use = "sig"
+		jwk.Alg = key.method.Alg()
+		jwk.Kid = key.id
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
+
+// JWKSHandler serves the JWKS document, e.g. on /.well-known/jwks.json
+func (j JSONWebToken) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		set, err := j.JWKS()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(set)
+	})
+}
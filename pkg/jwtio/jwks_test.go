@@ -0,0 +1,205 @@
+package jwtio_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKey stores the private key as PEM and returns the file
+func writeKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "private.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	return file
+}
+
+func writePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	return file
+}
+
+// jwkPublicKey rebuilds the public key from a JWK the way a relying party would
+func jwkPublicKey(t *testing.T, jwk jwtio.JWK) crypto.PublicKey {
+	decode := func(segment string) []byte {
+		b, err := jwt.DecodeSegment(segment)
+		require.NoError(t, err)
+
+		return b
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(decode(jwk.N)),
+			E: int(new(big.Int).SetBytes(decode(jwk.E)).Int64()),
+		}
+	case "EC":
+		require.Equal(t, "P-256", jwk.Crv)
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(decode(jwk.X)),
+			Y:     new(big.Int).SetBytes(decode(jwk.Y)),
+		}
+	case "OKP":
+		require.Equal(t, "Ed25519", jwk.Crv)
+
+		return ed25519.PublicKey(decode(jwk.X))
+	}
+
+	t.Fatalf("unexpected key type %s", jwk.Kty)
+
+	return nil
+}
+
+func newAsymmetricKeys(t *testing.T) []jwtio.KeyConfig {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return []jwtio.KeyConfig{
+		{ID: "hmac", Secret: "access-secret"},
+		{ID: "rsa", SigningMethod: "RS256", PrivateKeyFile: writeKey(t, rsaKey)},
+		{ID: "ec", SigningMethod: "ES256", PrivateKeyFile: writeKey(t, ecKey), PublicKeyFile: writePublicKey(t, ecKey.Public())},
+		{ID: "ed", SigningMethod: "EdDSA", PrivateKeyFile: writeKey(t, edKey)},
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	keys := newAsymmetricKeys(t)
+	cfg := &jwtio.Config{
+		RefreshTokenSecret: "refresh-secret",
+		AccessKeys:         keys,
+		ActiveAccessKey:    "rsa",
+	}
+
+	j, err := jwtio.NewJSONWebToken(cfg, newMemoryStore(t), newEpochSource("user-1"))
+	require.NoError(t, err)
+
+	set, err := j.JWKS()
+	require.NoError(t, err)
+
+	// The shared secret is never published
+	published := map[string]jwtio.JWK{}
+	for _, jwk := range set.Keys {
+		assert.Equal(t, "sig", jwk.Use)
+		published[jwk.Kid] = jwk
+	}
+
+	require.Len(t, published, 3)
+	assert.NotContains(t, published, "hmac")
+	assert.Equal(t, "RS256", published["rsa"].Alg)
+	assert.Equal(t, "ES256", published["ec"].Alg)
+	assert.Equal(t, "EdDSA", published["ed"].Alg)
+
+	// Tokens of every published key verify against its JWK and no other
+	for _, kid := range []string{"rsa", "ec", "ed"} {
+		cfg.ActiveAccessKey = kid
+		require.NoError(t, j.Reload(cfg))
+
+		token := createAccessToken(t, j)
+
+		_, err = j.VerifyAccessToken(token)
+		assert.NoError(t, err)
+
+		for other, jwk := range published {
+			_, err = jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
+				assert.Equal(t, kid, token.Header["kid"])
+				return jwkPublicKey(t, jwk), nil
+			})
+			if other == kid {
+				assert.NoError(t, err, kid)
+			} else {
+				assert.Error(t, err, "%s verified with %s", kid, other)
+			}
+		}
+	}
+}
+
+func TestJWKSRejectsBadKeys(t *testing.T) {
+	keys := newAsymmetricKeys(t)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  jwtio.KeyConfig
+	}{
+		{name: "unsupported method", key: jwtio.KeyConfig{ID: "bad", SigningMethod: "XS256", Secret: "secret"}},
+		{name: "missing secret", key: jwtio.KeyConfig{ID: "bad", SigningMethod: "HS256"}},
+		{name: "missing private key", key: jwtio.KeyConfig{ID: "bad", SigningMethod: "RS256"}},
+		{name: "wrong key type", key: jwtio.KeyConfig{ID: "bad", SigningMethod: "RS256", PrivateKeyFile: keys[2].PrivateKeyFile}},
+		{name: "wrong curve", key: jwtio.KeyConfig{ID: "bad", SigningMethod: "ES256", PrivateKeyFile: writeKey(t, ecKey)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &jwtio.Config{
+				RefreshTokenSecret: "refresh-secret",
+				AccessKeys:         []jwtio.KeyConfig{tt.key},
+				ActiveAccessKey:    tt.key.ID,
+			}
+
+			_, err := jwtio.NewJSONWebToken(cfg, newMemoryStore(t), newEpochSource("user-1"))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJWKSHandler(t *testing.T) {
+	cfg := &jwtio.Config{
+		RefreshTokenSecret: "refresh-secret",
+		AccessKeys:         newAsymmetricKeys(t),
+		ActiveAccessKey:    "ec",
+	}
+
+	j, err := jwtio.NewJSONWebToken(cfg, newMemoryStore(t), newEpochSource("user-1"))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	j.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var set jwtio.JWKSet
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+	assert.Len(t, set.Keys, 3)
+
+	rec = httptest.NewRecorder()
+	j.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/.well-known/jwks.json", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -3,117 +3,437 @@ package jwtio
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/digisata/auth-service/pkg/constants"
-	"github.com/digisata/auth-service/pkg/memcached"
 	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// SUBJECT_TYPE_USER and SUBJECT_TYPE_SERVICE tell a human caller apart from a
+	// machine identity. Tokens issued before the claim existed belong to users.
+	SUBJECT_TYPE_USER    string = "user"
+	SUBJECT_TYPE_SERVICE string = "service"
+)
+
 type (
 	Config struct {
-		AccessTokenExpiryHour  int    `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
-		RefreshTokenExpiryHour int    `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
-		AccessTokenSecret      string `mapstructure:"ACCESS_TOKEN_SECRET"`
-		RefreshTokenSecret     string `mapstructure:"REFRESH_TOKEN_SECRET"`
+		AccessTokenExpiryHour  int         `mapstructure:"ACCESS_TOKEN_EXPIRY_HOUR"`
+		RefreshTokenExpiryHour int         `mapstructure:"REFRESH_TOKEN_EXPIRY_HOUR"`
+		AccessTokenSecret      string      `mapstructure:"ACCESS_TOKEN_SECRET"`
+		RefreshTokenSecret     string      `mapstructure:"REFRESH_TOKEN_SECRET"`
+		SigningMethod          string      `mapstructure:"SIGNING_METHOD"`
+		PrivateKeyFile         string      `mapstructure:"PRIVATE_KEY_FILE"`
+		PublicKeyFile          string      `mapstructure:"PUBLIC_KEY_FILE"`
+		AccessKeys             []KeyConfig `mapstructure:"ACCESS_KEYS"`
+		RefreshKeys            []KeyConfig `mapstructure:"REFRESH_KEYS"`
+		ActiveAccessKey        string      `mapstructure:"ACTIVE_ACCESS_KEY"`
+		ActiveRefreshKey       string      `mapstructure:"ACTIVE_REFRESH_KEY"`
+		LegacyAccessKey        string      `mapstructure:"LEGACY_ACCESS_KEY"`
+		LegacyRefreshKey       string      `mapstructure:"LEGACY_REFRESH_KEY"`
+		Issuer                 string      `mapstructure:"ISSUER"`
+		Audience               string      `mapstructure:"AUDIENCE"`
 	}
 
 	Payload struct {
-		ID    string
-		Name  string
-		Email string
-		Role  int8
+		ID            string
+		Name          string
+		Email         string
+		EmailVerified bool
+		Role          int8
+		Audience      string
+		ClientID      string
+		SessionID     string
+		// Epoch is the token generation of the user, see RevokeUserTokens
+		Epoch int64
 	}
 
 	JSONWebToken struct {
-		cfg         *Config
-		memcachedDB *memcached.Database
+		cfg    *Config
+		store  TokenStore
+		epochs EpochSource
+		keys   *atomic.Pointer[keySet]
 	}
 
 	JwtCustomClaims struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-		Role int8   `json:"role"`
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Role    int8   `json:"role"`
+		Azp     string `json:"azp,omitempty"`
+		SubType string `json:"sub_type"`
+		Sid     string `json:"sid,omitempty"`
+		Epoch   int64  `json:"epoch"`
+		jwt.RegisteredClaims
+	}
+
+	// ServicePayload identifies a client acting on its own behalf
+	ServicePayload struct {
+		ClientID string
+		Scope    string
+	}
+
+	// JwtServiceClaims are the access token claims of a service, the subject is
+	// the client id and there is no user id or role
+	JwtServiceClaims struct {
+		Scope   string `json:"scope,omitempty"`
+		Azp     string `json:"azp"`
+		SubType string `json:"sub_type"`
 		jwt.RegisteredClaims
 	}
 
+	// JwtCustomRefreshClaims carry the family the token belongs to and the jti
+	// of the token it was rotated from
 	JwtCustomRefreshClaims struct {
-		ID string `json:"id"`
+		ID       string `json:"id"`
+		Azp      string `json:"azp,omitempty"`
+		FamilyID string `json:"fid"`
+		ParentID string `json:"parent,omitempty"`
+		Sid      string `json:"sid,omitempty"`
+		Epoch    int64  `json:"epoch"`
+		jwt.RegisteredClaims
+	}
+
+	// IDTokenClaims are the OpenID Connect ID token claims, the subject is the user id
+	IDTokenClaims struct {
+		Name          string `json:"name"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Nonce         string `json:"nonce,omitempty"`
+		AuthTime      int64  `json:"auth_time"`
+		Azp           string `json:"azp,omitempty"`
 		jwt.RegisteredClaims
 	}
 )
 
-func NewJSONWebToken(cfg *Config, memcachedDB *memcached.Database) *JSONWebToken {
-	return &JSONWebToken{
-		cfg:         cfg,
-		memcachedDB: memcachedDB,
+func NewJSONWebToken(cfg *Config, store TokenStore, epochs EpochSource) (*JSONWebToken, error) {
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &JSONWebToken{
+		cfg:    cfg,
+		store:  store,
+		epochs: epochs,
+		keys:   &atomic.Pointer[keySet]{},
+	}
+	j.keys.Store(keys)
+
+	return j, nil
+}
+
+// Reload swaps the keyrings for the ones described by cfg. Tokens signed by keys
+// that are still listed keep verifying, tokens signed by dropped keys are retired.
+func (j JSONWebToken) Reload(cfg *Config) error {
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		return err
+	}
+
+	j.keys.Store(keys)
+
+	return nil
+}
+
+// ActiveKeyIDs returns the ids of the keys currently signing access and refresh tokens
+func (j JSONWebToken) ActiveKeyIDs() (string, string) {
+	keys := j.keys.Load()
+
+	return keys.access.active, keys.refresh.active
+}
+
+func sign(claims jwt.Claims, key *signingKey) (string, error) {
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+
+	return token.SignedString(key.signKey)
+}
+
+// register marks a signed token live in the token store, which is what
+// verification checks before accepting it
+func (j JSONWebToken) register(jti string, record TokenRecord) error {
+	err := j.store.Save(jti, record)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
 	}
+
+	return nil
+}
+
+// audience is the client the token was issued to, or the configured audience
+// for first party logins
+func (j JSONWebToken) audience(payload Payload) jwt.ClaimStrings {
+	if payload.Audience != "" {
+		return jwt.ClaimStrings{payload.Audience}
+	}
+
+	if j.cfg.Audience != "" {
+		return jwt.ClaimStrings{j.cfg.Audience}
+	}
+
+	return nil
 }
 
-func (j JSONWebToken) CreateAccessToken(payload Payload, secret string, now time.Time, expiry int) (string, error) {
+func (j JSONWebToken) CreateAccessToken(payload Payload, now time.Time, expiry int) (string, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	expiresAt := now.Add(time.Hour * time.Duration(expiry))
 	claims := &JwtCustomClaims{
-		Name: payload.Name,
-		ID:   payload.ID,
-		Role: payload.Role,
+		Name:    payload.Name,
+		ID:      payload.ID,
+		Role:    payload.Role,
+		Azp:     payload.ClientID,
+		SubType: SUBJECT_TYPE_USER,
+		Sid:     payload.SessionID,
+		Epoch:   payload.Epoch,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    j.cfg.Issuer,
 			Subject:   payload.Email,
+			Audience:  j.audience(payload),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour * time.Duration(expiry))),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	t, err := sign(claims, j.keys.Load().access.signer())
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
 
-	t, err := token.SignedString([]byte(secret))
+	err = j.register(jti, TokenRecord{
+		Type:      TOKEN_TYPE_ACCESS,
+		UserID:    payload.ID,
+		ClientID:  payload.ClientID,
+		SessionID: payload.SessionID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return t, nil
+}
+
+// CreateServiceAccessToken signs an access token for the client credentials grant.
+// The audience is the configured resource audience, not the client itself.
+func (j JSONWebToken) CreateServiceAccessToken(payload ServicePayload, now time.Time, expiry int) (string, error) {
+	jti, err := newJTI()
 	if err != nil {
 		return "", status.Error(codes.Internal, err.Error())
 	}
 
+	expiresAt := now.Add(time.Hour * time.Duration(expiry))
+	claims := &JwtServiceClaims{
+		Scope:   payload.Scope,
+		Azp:     payload.ClientID,
+		SubType: SUBJECT_TYPE_SERVICE,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    j.cfg.Issuer,
+			Subject:   payload.ClientID,
+			Audience:  j.audience(Payload{}),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	t, err := sign(claims, j.keys.Load().access.signer())
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	err = j.register(jti, TokenRecord{
+		Type:      TOKEN_TYPE_ACCESS,
+		ClientID:  payload.ClientID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return t, nil
 }
 
-func (j JSONWebToken) CreateRefreshToken(payload Payload, secret string, now time.Time, expiry int) (string, error) {
+// Audience returns the audience of verified claims, the first one if there are
+// several
+func Audience(claims jwt.MapClaims) string {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud
+	case []interface{}:
+		if len(aud) > 0 {
+			aud, _ := aud[0].(string)
+			return aud
+		}
+	}
+
+	return ""
+}
+
+// IsService reports whether verified claims belong to a service rather than a user
+func IsService(claims jwt.MapClaims) bool {
+	subType, _ := claims["sub_type"].(string)
+
+	return subType == SUBJECT_TYPE_SERVICE
+}
+
+func (j JSONWebToken) CreateRefreshToken(payload Payload, family TokenFamily, now time.Time, expiry int) (string, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	if family.ID == "" {
+		family.ID = jti
+	}
+
+	expiresAt := now.Add(time.Hour * time.Duration(expiry))
 	claims := &JwtCustomRefreshClaims{
-		ID: payload.ID,
+		ID:       payload.ID,
+		Azp:      payload.ClientID,
+		FamilyID: family.ID,
+		ParentID: family.Parent,
+		Sid:      payload.SessionID,
+		Epoch:    payload.Epoch,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    j.cfg.Issuer,
 			Subject:   payload.Email,
+			Audience:  j.audience(payload),
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour * time.Duration(expiry))),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	rt, err := token.SignedString([]byte(secret))
+	rt, err := sign(claims, j.keys.Load().refresh.signer())
 	if err != nil {
 		return "", status.Error(codes.Internal, err.Error())
 	}
 
+	err = j.register(jti, TokenRecord{
+		Type:      TOKEN_TYPE_REFRESH,
+		UserID:    payload.ID,
+		ClientID:  payload.ClientID,
+		SessionID: payload.SessionID,
+		FamilyID:  family.ID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return rt, nil
 }
 
+// CreateIDToken signs an OpenID Connect ID token with the access token keyring,
+// so relying parties can verify it against the published JWKS
+func (j JSONWebToken) CreateIDToken(payload Payload, nonce string, now time.Time, expiry int) (string, error) {
+	claims := &IDTokenClaims{
+		Name:          payload.Name,
+		Email:         payload.Email,
+		EmailVerified: payload.EmailVerified,
+		Nonce:         nonce,
+		AuthTime:      now.Unix(),
+		Azp:           payload.ClientID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    j.cfg.Issuer,
+			Subject:   payload.ID,
+			Audience:  j.audience(payload),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour * time.Duration(expiry))),
+		},
+	}
+	it, err := sign(claims, j.keys.Load().access.signer())
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	return it, nil
+}
+
+// SigningAlgorithms returns the algorithms of the keys that may sign access and ID tokens
+func (j JSONWebToken) SigningAlgorithms() []string {
+	kr := j.keys.Load().access
+	algs := []string{}
+	seen := map[string]bool{}
+
+	for _, kid := range kr.order {
+		alg := kr.keys[kid].method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+
+	return algs
+}
+
 func (j JSONWebToken) Verify(ctx context.Context) (jwt.MapClaims, error) {
 	accessToken, err := j.GetAccessToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = j.memcachedDB.Get(accessToken)
+	return j.VerifyAccessToken(accessToken)
+}
+
+func (j JSONWebToken) VerifyAccessToken(accessToken string) (jwt.MapClaims, error) {
+	token, err := j.parse(accessToken, j.keys.Load().access)
 	if err != nil {
-		if errors.Is(err, memcache.ErrCacheMiss) {
-			return nil, status.Error(codes.Unauthenticated, constants.TOKEN_EXPIRED)
-		}
+		return nil, err
+	}
 
-		return nil, status.Error(codes.Internal, err.Error())
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok && !token.Valid {
+		return nil, status.Error(codes.Unauthenticated, constants.FAILED_TO_EXTRACT)
 	}
 
-	token, err := jwt.Parse(accessToken, func(token *jwt.Token) (interface{}, error) {
-		return j.validateToken(token, j.cfg.AccessTokenSecret)
-	})
+	err = j.checkLive(claims, TOKEN_TYPE_ACCESS, constants.TOKEN_EXPIRED)
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.checkRevoked(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.checkSessionRevoked(claims, constants.TOKEN_EXPIRED)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (j JSONWebToken) VerifyRefreshToken(refreshToken string) (jwt.MapClaims, error) {
+	claims, err := j.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.checkLive(claims, TOKEN_TYPE_REFRESH, constants.REFRESH_TOKEN_EXPIRED)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// ParseRefreshToken checks the signature and the revocations of a refresh token
+// without asking whether it is still live, so a token that was already rotated
+// can still be told apart from a forged one
+func (j JSONWebToken) ParseRefreshToken(refreshToken string) (jwt.MapClaims, error) {
+	token, err := j.parse(refreshToken, j.keys.Load().refresh)
 	if err != nil {
 		return nil, err
 	}
@@ -123,32 +443,84 @@ func (j JSONWebToken) Verify(ctx context.Context) (jwt.MapClaims, error) {
 		return nil, status.Error(codes.Unauthenticated, constants.FAILED_TO_EXTRACT)
 	}
 
+	err = j.checkRevoked(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.checkFamilyRevoked(claims, constants.REFRESH_TOKEN_EXPIRED)
+	if err != nil {
+		return nil, err
+	}
+
+	err = j.checkSessionRevoked(claims, constants.REFRESH_TOKEN_EXPIRED)
+	if err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
 
-func (j JSONWebToken) VerifyRefreshToken(refreshToken, secret string) (jwt.MapClaims, error) {
-	_, err := j.memcachedDB.Get(refreshToken)
+// checkLive rejects tokens the token store no longer holds, because they expired
+// or were retired. Tokens issued before they carried a jti were never stored
+// under one and count as expired.
+func (j JSONWebToken) checkLive(claims jwt.MapClaims, tokenType, reason string) error {
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return status.Error(codes.Unauthenticated, reason)
+	}
+
+	record, err := j.store.Get(jti)
 	if err != nil {
-		if errors.Is(err, memcache.ErrCacheMiss) {
-			return nil, status.Error(codes.Unauthenticated, constants.REFRESH_TOKEN_EXPIRED)
+		if errors.Is(err, ErrTokenNotFound) {
+			return status.Error(codes.Unauthenticated, reason)
 		}
 
-		return nil, status.Error(codes.Internal, err.Error())
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if record.Type != tokenType {
+		return status.Error(codes.Unauthenticated, reason)
 	}
 
-	token, err := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-		return j.validateToken(token, j.cfg.RefreshTokenSecret)
+	return nil
+}
+
+// RetireAccessToken ends an access token before it expires. Tokens that do not
+// verify or are no longer live have nothing to retire and are ignored.
+func (j JSONWebToken) RetireAccessToken(accessToken string) error {
+	return j.retire(accessToken, j.keys.Load().access)
+}
+
+// RetireRefreshToken ends a refresh token before it expires, like RetireAccessToken
+func (j JSONWebToken) RetireRefreshToken(refreshToken string) error {
+	return j.retire(refreshToken, j.keys.Load().refresh)
+}
+
+func (j JSONWebToken) retire(t string, kr *keyring) error {
+	token, err := jwt.Parse(t, func(token *jwt.Token) (interface{}, error) {
+		return j.validateToken(token, kr)
 	})
 	if err != nil {
-		return nil, err
+		return nil
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok && !token.Valid {
-		return nil, status.Error(codes.Unauthenticated, constants.FAILED_TO_EXTRACT)
+	if !ok {
+		return nil
 	}
 
-	return claims, nil
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return nil
+	}
+
+	err = j.store.Delete(jti)
+	if err != nil && !errors.Is(err, ErrTokenNotFound) {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
 }
 
 func ExtractValueFromToken[T string | int8](token *jwt.Token, key string) (T, error) {
@@ -161,13 +533,36 @@ func ExtractValueFromToken[T string | int8](token *jwt.Token, key string) (T, er
 	return claims[key].(T), nil
 }
 
-func (j JSONWebToken) validateToken(token *jwt.Token, secret string) (interface{}, error) {
-	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-		return nil, status.Error(codes.Unauthenticated, constants.UNEXPECTED_SIGNING_METHOD)
+// parse checks the signature of a token against the keyring. A malformed,
+// expired or forged token is unauthenticated like one signed by an unknown key.
+func (j JSONWebToken) parse(tokenString string, kr *keyring) (*jwt.Token, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return j.validateToken(token, kr)
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unknown {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
 
+		return nil, err
 	}
 
-	return []byte(secret), nil
+	return token, nil
+}
+
+func (j JSONWebToken) validateToken(token *jwt.Token, kr *keyring) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := kr.lookup(kid)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, constants.UNKNOWN_SIGNING_KEY)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, status.Error(codes.Unauthenticated, fmt.Sprintf(constants.UNEXPECTED_SIGNING_METHOD, token.Method.Alg()))
+	}
+
+	return key.verifyKey, nil
 }
 
 func (j JSONWebToken) GetAccessToken(ctx context.Context) (string, error) {
@@ -192,3 +587,33 @@ func (j JSONWebToken) GetAccessToken(ctx context.Context) (string, error) {
 
 	return split[1], nil
 }
+
+// GetBasicCredentials reads client credentials sent as HTTP basic authorization
+func (j JSONWebToken) GetBasicCredentials(ctx context.Context) (string, string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", status.Error(codes.Unauthenticated, "Metadata is not provided")
+	}
+
+	values := md["authorization"]
+	if len(values) == 0 {
+		return "", "", status.Error(codes.Unauthenticated, "Client credentials are not provided")
+	}
+
+	split := strings.Split(values[0], " ")
+	if len(split) != 2 || split[0] != "Basic" {
+		return "", "", status.Error(codes.Unauthenticated, "Invalid client credentials format")
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(split[1])
+	if err != nil {
+		return "", "", status.Error(codes.Unauthenticated, "Invalid client credentials format")
+	}
+
+	clientID, clientSecret, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", status.Error(codes.Unauthenticated, "Invalid client credentials format")
+	}
+
+	return clientID, clientSecret, nil
+}
@@ -0,0 +1,188 @@
+package jwtio_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/cache"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// epochSource keeps token generations in a map, users missing from it do not exist
+type epochSource struct {
+	mu     sync.Mutex
+	epochs map[string]int64
+}
+
+func newEpochSource(userIDs ...string) *epochSource {
+	s := &epochSource{epochs: map[string]int64{}}
+	for _, userID := range userIDs {
+		s.epochs[userID] = 0
+	}
+
+	return s
+}
+
+func (s *epochSource) TokenEpoch(ctx context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	epoch, ok := s.epochs[userID]
+	if !ok {
+		return 0, jwtio.ErrUserNotFound
+	}
+
+	return epoch, nil
+}
+
+func (s *epochSource) NextTokenEpoch(ctx context.Context, userID string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.epochs[userID]; !ok {
+		return 0, jwtio.ErrUserNotFound
+	}
+
+	s.epochs[userID]++
+
+	return s.epochs[userID], nil
+}
+
+func newMemoryStore(t *testing.T) jwtio.TokenStore {
+	memory := cache.NewMemory(cache.Config{}, nil)
+	t.Cleanup(memory.Close)
+
+	return jwtio.NewMemoryTokenStore(memory)
+}
+
+func newJSONWebToken(t *testing.T, store jwtio.TokenStore) *jwtio.JSONWebToken {
+	j, err := jwtio.NewJSONWebToken(&jwtio.Config{
+		AccessTokenSecret:  "access-secret",
+		RefreshTokenSecret: "refresh-secret",
+	}, store, newEpochSource("user-1"))
+	require.NoError(t, err)
+
+	return j
+}
+
+func TestTokensAreStoredByJTI(t *testing.T) {
+	store := newMemoryStore(t)
+	j := newJSONWebToken(t, store)
+	payload := jwtio.Payload{
+		ID:        "user-1",
+		Name:      strings.Repeat("n", 400),
+		Email:     "user@example.com",
+		SessionID: "session-1",
+	}
+
+	accessToken, err := j.CreateAccessToken(payload, time.Now(), 1)
+	require.NoError(t, err)
+
+	claims, err := j.VerifyAccessToken(accessToken)
+	require.NoError(t, err)
+
+	jti := claims["jti"].(string)
+	record, err := store.Get(jti)
+	require.NoError(t, err)
+	assert.Equal(t, jwtio.TOKEN_TYPE_ACCESS, record.Type)
+	assert.Equal(t, "session-1", record.SessionID)
+	assert.Less(t, len(jti), 64)
+	assert.Greater(t, len(accessToken), 250)
+
+	// A refresh token is not accepted as an access token
+	refreshToken, err := j.CreateRefreshToken(payload, jwtio.TokenFamily{}, time.Now(), 1)
+	require.NoError(t, err)
+	_, err = j.VerifyAccessToken(refreshToken)
+	assert.Error(t, err)
+
+	require.NoError(t, j.RetireAccessToken(accessToken))
+	_, err = j.VerifyAccessToken(accessToken)
+	assert.Error(t, err)
+
+	// Retiring twice is not an error
+	assert.NoError(t, j.RetireAccessToken(accessToken))
+}
+
+func TestConsumeRefreshToken(t *testing.T) {
+	j := newJSONWebToken(t, newMemoryStore(t))
+	payload := jwtio.Payload{ID: "user-1", SessionID: "session-1"}
+
+	refreshToken, err := j.CreateRefreshToken(payload, jwtio.TokenFamily{}, time.Now(), 1)
+	require.NoError(t, err)
+
+	claims, err := j.VerifyRefreshToken(refreshToken)
+	require.NoError(t, err)
+
+	family, err := j.ConsumeRefreshToken(claims)
+	require.NoError(t, err)
+	assert.Equal(t, claims["jti"], family.ID)
+	assert.Equal(t, claims["jti"], family.Parent)
+
+	_, err = j.VerifyRefreshToken(refreshToken)
+	assert.Error(t, err)
+
+	_, err = j.ConsumeRefreshToken(claims)
+	assert.ErrorIs(t, err, jwtio.ErrTokenReused)
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	j := newJSONWebToken(t, newMemoryStore(t))
+	payload := jwtio.Payload{ID: "user-1"}
+
+	accessToken, err := j.CreateAccessToken(payload, time.Now(), 1)
+	require.NoError(t, err)
+
+	require.NoError(t, j.RevokeUserTokens("user-1"))
+	_, err = j.VerifyAccessToken(accessToken)
+	assert.Error(t, err)
+
+	payload.Epoch, err = j.TokenEpoch("user-1")
+	require.NoError(t, err)
+
+	accessToken, err = j.CreateAccessToken(payload, time.Now(), 1)
+	require.NoError(t, err)
+	_, err = j.VerifyAccessToken(accessToken)
+	assert.NoError(t, err)
+}
+
+func TestRevocationSurvivesEviction(t *testing.T) {
+	memory := cache.NewMemory(cache.Config{}, nil)
+	defer memory.Close()
+
+	epochs := newEpochSource("user-1")
+	j, err := jwtio.NewJSONWebToken(&jwtio.Config{
+		AccessTokenSecret:  "access-secret",
+		RefreshTokenSecret: "refresh-secret",
+	}, jwtio.NewMemoryTokenStore(memory), epochs)
+	require.NoError(t, err)
+
+	accessToken, err := j.CreateAccessToken(jwtio.Payload{ID: "user-1"}, time.Now(), 1)
+	require.NoError(t, err)
+
+	require.NoError(t, j.RevokeUserTokens("user-1"))
+
+	// The cached generation is gone, the epoch source still knows the revocation
+	memory.Delete(jwtio.TOKEN_EPOCH_KEY_PREFIX + "user-1")
+
+	_, err = j.VerifyAccessToken(accessToken)
+	assert.Error(t, err)
+
+	epoch, err := j.TokenEpoch("user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), epoch)
+}
+
+func TestTokensOfUnknownUsersAreRevoked(t *testing.T) {
+	j := newJSONWebToken(t, newMemoryStore(t))
+
+	accessToken, err := j.CreateAccessToken(jwtio.Payload{ID: "user-2"}, time.Now(), 1)
+	require.NoError(t, err)
+
+	_, err = j.VerifyAccessToken(accessToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package jwtio
+
+import (
+	"fmt"
+)
+
+const defaultKeyID = "default"
+
+type (
+	KeyConfig struct {
+		ID             string `mapstructure:"ID"`
+		SigningMethod  string `mapstructure:"SIGNING_METHOD"`
+		Secret         string `mapstructure:"SECRET"`
+		PrivateKeyFile string `mapstructure:"PRIVATE_KEY_FILE"`
+		PublicKeyFile  string `mapstructure:"PUBLIC_KEY_FILE"`
+	}
+
+	// keyring holds every key that may verify a token type. Only the active key
+	// signs new tokens, the others stay valid until they are removed from config.
+	keyring struct {
+		keys   map[string]*signingKey
+		order  []string
+		active string
+		// legacy verifies tokens issued before they carried a kid
+		legacy string
+	}
+
+	keySet struct {
+		access  *keyring
+		refresh *keyring
+	}
+)
+
+func newKeyring(keys []KeyConfig, active, legacy string) (*keyring, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one signing key is required")
+	}
+
+	kr := &keyring{
+		keys: make(map[string]*signingKey, len(keys)),
+	}
+
+	for _, kc := range keys {
+		if kc.ID == "" {
+			return nil, fmt.Errorf("signing key id is required")
+		}
+
+		if _, ok := kr.keys[kc.ID]; ok {
+			return nil, fmt.Errorf("duplicate signing key id: %s", kc.ID)
+		}
+
+		key, err := newSigningKey(kc.SigningMethod, kc.Secret, kc.PrivateKeyFile, kc.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", kc.ID, err)
+		}
+
+		key.id = kc.ID
+		kr.keys[kc.ID] = key
+		kr.order = append(kr.order, kc.ID)
+	}
+
+	if active == "" {
+		active = kr.order[0]
+	}
+
+	if _, ok := kr.keys[active]; !ok {
+		return nil, fmt.Errorf("active signing key %s is not in the keyring", active)
+	}
+
+	kr.active = active
+
+	if _, ok := kr.keys[legacy]; legacy != "" && !ok {
+		return nil, fmt.Errorf("legacy signing key %s is not in the keyring", legacy)
+	}
+
+	kr.legacy = legacy
+
+	return kr, nil
+}
+
+func (kr *keyring) signer() *signingKey {
+	return kr.keys[kr.active]
+}
+
+// lookup resolves the key for a token kid. Tokens issued before key ids were
+// introduced have no kid, they are checked against the legacy key and refused
+// when the keyring has none.
+func (kr *keyring) lookup(kid string) (*signingKey, bool) {
+	if kid == "" {
+		kid = kr.legacy
+	}
+
+	key, ok := kr.keys[kid]
+
+	return key, ok
+}
+
+func newKeySet(cfg *Config) (*keySet, error) {
+	// The single key settings signed the tokens from before key ids as well
+	accessKeys, legacyAccessKey := cfg.AccessKeys, cfg.LegacyAccessKey
+	if len(accessKeys) == 0 {
+		accessKeys = []KeyConfig{{
+			ID:             defaultKeyID,
+			SigningMethod:  cfg.SigningMethod,
+			Secret:         cfg.AccessTokenSecret,
+			PrivateKeyFile: cfg.PrivateKeyFile,
+			PublicKeyFile:  cfg.PublicKeyFile,
+		}}
+		legacyAccessKey = defaultKeyID
+	}
+
+	// Refresh tokens are only ever verified by this service, so by default they stay on HMAC.
+	refreshKeys, legacyRefreshKey := cfg.RefreshKeys, cfg.LegacyRefreshKey
+	if len(refreshKeys) == 0 {
+		refreshKeys = []KeyConfig{{
+			ID:     defaultKeyID,
+			Secret: cfg.RefreshTokenSecret,
+		}}
+		legacyRefreshKey = defaultKeyID
+	}
+
+	access, err := newKeyring(accessKeys, cfg.ActiveAccessKey, legacyAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("access keyring: %w", err)
+	}
+
+	refresh, err := newKeyring(refreshKeys, cfg.ActiveRefreshKey, legacyRefreshKey)
+	if err != nil {
+		return nil, fmt.Errorf("refresh keyring: %w", err)
+	}
+
+	return &keySet{
+		access:  access,
+		refresh: refresh,
+	}, nil
+}
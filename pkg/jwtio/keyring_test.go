@@ -0,0 +1,147 @@
+package jwtio_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newKeyringConfig(active string, ids ...string) *jwtio.Config {
+	cfg := &jwtio.Config{
+		RefreshTokenSecret: "refresh-secret",
+		ActiveAccessKey:    active,
+	}
+
+	for _, id := range ids {
+		cfg.AccessKeys = append(cfg.AccessKeys, jwtio.KeyConfig{ID: id, Secret: "secret-" + id})
+	}
+
+	return cfg
+}
+
+func createAccessToken(t *testing.T, j *jwtio.JSONWebToken) string {
+	token, err := j.CreateAccessToken(jwtio.Payload{ID: "user-1"}, time.Now(), 1)
+	require.NoError(t, err)
+
+	return token
+}
+
+func kid(t *testing.T, token string) string {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+
+	kid, _ := parsed.Header["kid"].(string)
+
+	return kid
+}
+
+// withoutKid signs the claims of token again with secret, the way tokens were
+// signed before they carried a kid
+func withoutKid(t *testing.T, token, secret string) string {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+
+	resigned, err := jwt.NewWithClaims(jwt.SigningMethodHS256, parsed.Claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return resigned
+}
+
+func TestKeyringRotation(t *testing.T) {
+	j, err := jwtio.NewJSONWebToken(newKeyringConfig("key-1", "key-1", "key-2"), newMemoryStore(t), newEpochSource("user-1"))
+	require.NoError(t, err)
+
+	first := createAccessToken(t, j)
+	assert.Equal(t, "key-1", kid(t, first))
+
+	// The next key signs, tokens of the previous one keep verifying
+	require.NoError(t, j.Reload(newKeyringConfig("key-2", "key-1", "key-2")))
+
+	second := createAccessToken(t, j)
+	assert.Equal(t, "key-2", kid(t, second))
+
+	_, err = j.VerifyAccessToken(first)
+	assert.NoError(t, err)
+
+	_, err = j.VerifyAccessToken(second)
+	assert.NoError(t, err)
+
+	// Retiring a key retires its tokens
+	require.NoError(t, j.Reload(newKeyringConfig("key-2", "key-2")))
+
+	_, err = j.VerifyAccessToken(first)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, err.Error(), constants.UNKNOWN_SIGNING_KEY)
+
+	_, err = j.VerifyAccessToken(second)
+	assert.NoError(t, err)
+
+	// A broken config leaves the keys in use alone
+	assert.Error(t, j.Reload(newKeyringConfig("key-3", "key-2")))
+
+	_, err = j.VerifyAccessToken(second)
+	assert.NoError(t, err)
+}
+
+func TestKeyringRejectsForeignKeys(t *testing.T) {
+	j, err := jwtio.NewJSONWebToken(newKeyringConfig("key-1", "key-1"), newMemoryStore(t), newEpochSource("user-1"))
+	require.NoError(t, err)
+
+	token := createAccessToken(t, j)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, parsed.Claims)
+	forged.Header["kid"] = "key-1"
+
+	signed, err := forged.SignedString([]byte("guessed-secret"))
+	require.NoError(t, err)
+
+	_, err = j.VerifyAccessToken(signed)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Switching the algorithm of a key is refused too
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, parsed.Claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	_, err = j.VerifyAccessToken(none)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestKeyringTokensWithoutKid(t *testing.T) {
+	// With the single key settings the key signed tokens from before key ids too
+	j := newJSONWebToken(t, newMemoryStore(t))
+
+	legacy := withoutKid(t, createAccessToken(t, j), "access-secret")
+
+	_, err := j.VerifyAccessToken(legacy)
+	assert.NoError(t, err)
+
+	// A configured keyring refuses them, unless it names the key that signed them
+	cfg := newKeyringConfig("key-2", "key-1", "key-2")
+
+	j, err = jwtio.NewJSONWebToken(cfg, newMemoryStore(t), newEpochSource("user-1"))
+	require.NoError(t, err)
+
+	legacy = withoutKid(t, createAccessToken(t, j), "secret-key-1")
+
+	_, err = j.VerifyAccessToken(legacy)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	cfg.LegacyAccessKey = "key-1"
+	require.NoError(t, j.Reload(cfg))
+
+	_, err = j.VerifyAccessToken(legacy)
+	assert.NoError(t, err)
+
+	cfg.LegacyAccessKey = "key-3"
+	assert.Error(t, j.Reload(cfg))
+}
@@ -0,0 +1,173 @@
+package jwtio
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+type signingKey struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// newSigningKey builds a key for the given signing method. HMAC methods use the
+// shared secret, every other method loads its key pair from PEM files. The public
+// key file is optional, when empty the public key is derived from the private key.
+func newSigningKey(method, secret, privateKeyFile, publicKeyFile string) (*signingKey, error) {
+	if method == "" {
+		method = jwt.SigningMethodHS256.Alg()
+	}
+
+	signingMethod := jwt.GetSigningMethod(method)
+	if signingMethod == nil {
+		return nil, fmt.Errorf("unsupported signing method: %s", method)
+	}
+
+	if _, ok := signingMethod.(*jwt.SigningMethodHMAC); ok {
+		if secret == "" {
+			return nil, fmt.Errorf("secret is required for signing method %s", method)
+		}
+
+		return &signingKey{
+			method:    signingMethod,
+			signKey:   []byte(secret),
+			verifyKey: []byte(secret),
+		}, nil
+	}
+
+	if privateKeyFile == "" {
+		return nil, fmt.Errorf("private key file is required for signing method %s", method)
+	}
+
+	privatePEM, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var privateKey crypto.Signer
+
+	switch signingMethod.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+	case *jwt.SigningMethodECDSA:
+		privateKey, err = jwt.ParseECPrivateKeyFromPEM(privatePEM)
+	case *jwt.SigningMethodEd25519:
+		var key crypto.PrivateKey
+		key, err = jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+		if err == nil {
+			privateKey = key.(ed25519.PrivateKey)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signing method: %s", method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse private key %s: %w", privateKeyFile, err)
+	}
+
+	publicKey := privateKey.Public()
+	if publicKeyFile != "" {
+		publicKey, err = loadPublicKey(signingMethod, publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ecKey, ok := publicKey.(*ecdsa.PublicKey); ok {
+		if ecKey.Curve.Params().BitSize != signingMethod.(*jwt.SigningMethodECDSA).CurveBits {
+			return nil, fmt.Errorf("curve %s does not match signing method %s", ecKey.Curve.Params().Name, method)
+		}
+	}
+
+	return &signingKey{
+		method:    signingMethod,
+		signKey:   privateKey,
+		verifyKey: publicKey,
+	}, nil
+}
+
+func loadPublicKey(method jwt.SigningMethod, publicKeyFile string) (crypto.PublicKey, error) {
+	publicPEM, err := os.ReadFile(publicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKey crypto.PublicKey
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		publicKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM)
+	case *jwt.SigningMethodECDSA:
+		publicKey, err = jwt.ParseECPublicKeyFromPEM(publicPEM)
+	case *jwt.SigningMethodEd25519:
+		publicKey, err = jwt.ParseEdPublicKeyFromPEM(publicPEM)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse public key %s: %w", publicKeyFile, err)
+	}
+
+	return publicKey, nil
+}
+
+func (k signingKey) isSymmetric() bool {
+	_, ok := k.method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+func curveName(curve elliptic.Curve) string {
+	switch curve {
+	case elliptic.P256():
+		return "P-256"
+	case elliptic.P384():
+		return "P-384"
+	case elliptic.P521():
+		return "P-521"
+	}
+
+	return curve.Params().Name
+}
+
+func publicKeyToJWK(key crypto.PublicKey) (JWK, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   jwt.EncodeSegment(k.N.Bytes()),
+			E:   jwt.EncodeSegment(bigEndian(k.E)),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kty: "EC",
+			Crv: curveName(k.Curve),
+			X:   jwt.EncodeSegment(k.X.FillBytes(make([]byte, size))),
+			Y:   jwt.EncodeSegment(k.Y.FillBytes(make([]byte, size))),
+		}, nil
+	case ed25519.PublicKey:
+		return JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   jwt.EncodeSegment(k),
+		}, nil
+	}
+
+	return JWK{}, fmt.Errorf("unsupported public key type %T", key)
+}
+
+func bigEndian(n int) []byte {
+	var b []byte
+	for n > 0 {
+		b = append([]byte{byte(n)}, b...)
+		n >>= 8
+	}
+
+	return b
+}
@@ -0,0 +1,146 @@
+package jwtio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/digisata/auth-service/pkg/memcached"
+)
+
+// casAttempts bounds how often an epoch is retried when other instances keep
+// updating it in between
+const casAttempts = 5
+
+// MemcachedTokenStore keeps token state in memcached. Records expire with the
+// tokens they describe, so retired tokens never have to be cleaned up.
+type MemcachedTokenStore struct {
+	db *memcached.Database
+}
+
+var _ TokenStore = (*MemcachedTokenStore)(nil)
+
+func NewMemcachedTokenStore(db *memcached.Database) *MemcachedTokenStore {
+	return &MemcachedTokenStore{
+		db: db,
+	}
+}
+
+func (s MemcachedTokenStore) Save(jti string, record TokenRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Set(&memcache.Item{
+		Key:        TOKEN_KEY_PREFIX + jti,
+		Value:      value,
+		Expiration: int32(record.ExpiresAt),
+	})
+}
+
+func (s MemcachedTokenStore) Get(jti string) (TokenRecord, error) {
+	var record TokenRecord
+
+	it, err := s.db.Get(TOKEN_KEY_PREFIX + jti)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return record, ErrTokenNotFound
+		}
+
+		return record, err
+	}
+
+	err = json.Unmarshal(it.Value, &record)
+	if err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
+
+func (s MemcachedTokenStore) Delete(jti string) error {
+	err := s.db.Delete(TOKEN_KEY_PREFIX + jti)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return ErrTokenNotFound
+	}
+
+	return err
+}
+
+func (s MemcachedTokenStore) Mark(kind, id string, until time.Time) error {
+	return s.db.Set(&memcache.Item{
+		Key:        kind + ":" + id,
+		Value:      []byte("1"),
+		Expiration: int32(until.Unix()),
+	})
+}
+
+func (s MemcachedTokenStore) Marked(kind, id string) (bool, error) {
+	_, err := s.db.Get(kind + ":" + id)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s MemcachedTokenStore) Epoch(userID string) (int64, error) {
+	it, err := s.db.Get(TOKEN_EPOCH_KEY_PREFIX + userID)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, ErrEpochNotFound
+		}
+
+		return 0, err
+	}
+
+	return strconv.ParseInt(string(it.Value), 10, 64)
+}
+
+func (s MemcachedTokenStore) CacheEpoch(userID string, epoch int64, until time.Time) error {
+	key := TOKEN_EPOCH_KEY_PREFIX + userID
+	value := []byte(strconv.FormatInt(epoch, 10))
+
+	for i := 0; i < casAttempts; i++ {
+		it, err := s.db.Get(key)
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			err = s.db.Add(&memcache.Item{Key: key, Value: value, Expiration: int32(until.Unix())})
+
+			// Someone else cached a generation in between, compare against theirs
+			if errors.Is(err, memcache.ErrNotStored) {
+				continue
+			}
+
+			return err
+		}
+
+		if err != nil {
+			return err
+		}
+
+		current, err := strconv.ParseInt(string(it.Value), 10, 64)
+		if err == nil && current >= epoch {
+			return nil
+		}
+
+		it.Value = value
+		it.Expiration = int32(until.Unix())
+
+		err = s.db.CompareAndSwap(it)
+		if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) {
+			continue
+		}
+
+		return err
+	}
+
+	return fmt.Errorf("token epoch %s is contended", key)
+}
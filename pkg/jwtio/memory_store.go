@@ -0,0 +1,85 @@
+package jwtio
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/cache"
+)
+
+// MemoryTokenStore keeps token state in process, so tokens are only known to
+// the instance that issued them. Marks are pinned, a bounded cache never evicts
+// a revocation before it expires. Evicted token generations are read from the
+// epoch source again.
+type MemoryTokenStore struct {
+	memory *cache.Memory
+}
+
+var _ TokenStore = (*MemoryTokenStore)(nil)
+
+func NewMemoryTokenStore(memory *cache.Memory) *MemoryTokenStore {
+	return &MemoryTokenStore{
+		memory: memory,
+	}
+}
+
+func (s MemoryTokenStore) Save(jti string, record TokenRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.memory.Set(TOKEN_KEY_PREFIX+jti, value, record.ExpiresAt)
+
+	return nil
+}
+
+func (s MemoryTokenStore) Get(jti string) (TokenRecord, error) {
+	var record TokenRecord
+
+	value, ok := s.memory.Get(TOKEN_KEY_PREFIX + jti)
+	if !ok {
+		return record, ErrTokenNotFound
+	}
+
+	err := json.Unmarshal(value, &record)
+	if err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
+
+func (s MemoryTokenStore) Delete(jti string) error {
+	if !s.memory.Delete(TOKEN_KEY_PREFIX + jti) {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (s MemoryTokenStore) Mark(kind, id string, until time.Time) error {
+	s.memory.Pin(kind+":"+id, []byte("1"), until.Unix())
+
+	return nil
+}
+
+func (s MemoryTokenStore) Marked(kind, id string) (bool, error) {
+	_, ok := s.memory.Get(kind + ":" + id)
+
+	return ok, nil
+}
+
+func (s MemoryTokenStore) Epoch(userID string) (int64, error) {
+	value, ok := s.memory.Get(TOKEN_EPOCH_KEY_PREFIX + userID)
+	if !ok {
+		return 0, ErrEpochNotFound
+	}
+
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+func (s MemoryTokenStore) CacheEpoch(userID string, epoch int64, until time.Time) error {
+	return s.memory.SetMax(TOKEN_EPOCH_KEY_PREFIX+userID, epoch, until.Unix())
+}
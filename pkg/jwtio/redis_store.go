@@ -0,0 +1,97 @@
+package jwtio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisTokenStore keeps token state in redis, under the same keys as the
+// memcached store
+type RedisTokenStore struct {
+	db *redis.Database
+}
+
+var _ TokenStore = (*RedisTokenStore)(nil)
+
+func NewRedisTokenStore(db *redis.Database) *RedisTokenStore {
+	return &RedisTokenStore{
+		db: db,
+	}
+}
+
+func (s RedisTokenStore) Save(jti string, record TokenRecord) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Set(context.Background(), TOKEN_KEY_PREFIX+jti, value, record.ExpiresAt)
+}
+
+func (s RedisTokenStore) Get(jti string) (TokenRecord, error) {
+	var record TokenRecord
+
+	value, err := s.db.Rdb.Get(context.Background(), TOKEN_KEY_PREFIX+jti).Bytes()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return record, ErrTokenNotFound
+		}
+
+		return record, err
+	}
+
+	err = json.Unmarshal(value, &record)
+	if err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
+
+func (s RedisTokenStore) Delete(jti string) error {
+	deleted, err := s.db.Rdb.Del(context.Background(), TOKEN_KEY_PREFIX+jti).Result()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (s RedisTokenStore) Mark(kind, id string, until time.Time) error {
+	return s.db.Set(context.Background(), kind+":"+id, "1", until.Unix())
+}
+
+func (s RedisTokenStore) Marked(kind, id string) (bool, error) {
+	n, err := s.db.Rdb.Exists(context.Background(), kind+":"+id).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (s RedisTokenStore) Epoch(userID string) (int64, error) {
+	epoch, err := s.db.Rdb.Get(context.Background(), TOKEN_EPOCH_KEY_PREFIX+userID).Int64()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return 0, ErrEpochNotFound
+		}
+
+		return 0, err
+	}
+
+	return epoch, nil
+}
+
+func (s RedisTokenStore) CacheEpoch(userID string, epoch int64, until time.Time) error {
+	return s.db.SetMax(context.Background(), TOKEN_EPOCH_KEY_PREFIX+userID, epoch, until.Unix())
+}
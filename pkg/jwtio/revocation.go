@@ -0,0 +1,105 @@
+package jwtio
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TokenEpoch returns the current token generation of a user, which every token
+// issued to the user carries. Users that never had their tokens revoked are at 0.
+// The token store only caches generations, one it does not hold is read from the
+// epoch source, so an eviction never brings revoked tokens back.
+func (j JSONWebToken) TokenEpoch(userID string) (int64, error) {
+	epoch, err := j.store.Epoch(userID)
+	if err == nil {
+		return epoch, nil
+	}
+
+	if !errors.Is(err, ErrEpochNotFound) {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	epoch, err = j.epochs.TokenEpoch(context.Background(), userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return 0, revokedError()
+		}
+
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	err = j.store.CacheEpoch(userID, epoch, time.Now().Add(TOKEN_EPOCH_CACHE_TTL))
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	return epoch, nil
+}
+
+// RevokeUserTokens retires every access and refresh token issued to the user so
+// far. Tokens are not indexed by user, so instead of deleting them the user moves
+// on to the next generation and tokens of an older one are rejected.
+func (j JSONWebToken) RevokeUserTokens(userID string) error {
+	epoch, err := j.epochs.NextTokenEpoch(context.Background(), userID)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = j.store.CacheEpoch(userID, epoch, time.Now().Add(TOKEN_EPOCH_CACHE_TTL))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// checkRevoked rejects user tokens of an older generation than the user's
+// current one, and the tokens of users that no longer exist. Tokens issued before
+// generations existed count as generation 0. Generations are counted rather than
+// timed, so a token issued in the same second as a revocation is never mistaken
+// for one it revoked.
+func (j JSONWebToken) checkRevoked(claims jwt.MapClaims) error {
+	userID, _ := claims["id"].(string)
+	if userID == "" {
+		return nil
+	}
+
+	current, err := j.TokenEpoch(userID)
+	if err != nil {
+		return err
+	}
+
+	epoch, _ := claims["epoch"].(float64)
+	if int64(epoch) < current {
+		return revokedError()
+	}
+
+	return nil
+}
+
+// revokedError tells clients apart an expired token, which may be refreshed,
+// from a revoked one, after which the user has to sign in again
+func revokedError() error {
+	st := status.New(codes.Unauthenticated, constants.TOKEN_REVOKED)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: constants.REASON_TOKEN_REVOKED,
+		Domain: constants.ERROR_DOMAIN,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,40 @@
+package jwtio
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession retires the access and refresh tokens of a session until the
+// given time, by which every token of the session has expired anyway
+func (j JSONWebToken) RevokeSession(sessionID string, until time.Time) error {
+	err := j.store.Mark(MARK_REVOKED_SESSION, sessionID, until)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// checkSessionRevoked rejects tokens of a revoked session. Tokens issued before
+// sessions existed have none and are not checked.
+func (j JSONWebToken) checkSessionRevoked(claims jwt.MapClaims, reason string) error {
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		return nil
+	}
+
+	revoked, err := j.store.Marked(MARK_REVOKED_SESSION, sessionID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if revoked {
+		return status.Error(codes.Unauthenticated, reason)
+	}
+
+	return nil
+}
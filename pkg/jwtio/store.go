@@ -0,0 +1,84 @@
+package jwtio
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	TOKEN_TYPE_ACCESS  string = "access"
+	TOKEN_TYPE_REFRESH string = "refresh"
+
+	// Token stores keep live tokens and the token generation of users under
+	// these prefixes, marks under their kind
+	TOKEN_KEY_PREFIX       string = "token:"
+	TOKEN_EPOCH_KEY_PREFIX string = "token_epoch:"
+
+	// MARK_ kinds name what an id marked in the token store stands for
+	MARK_REVOKED_SESSION string = "revoked_session"
+	MARK_REVOKED_FAMILY  string = "revoked_family"
+	MARK_ROTATED         string = "refresh_rotated"
+
+	// TOKEN_EPOCH_CACHE_TTL is how long a token store keeps the token generation
+	// of a user before it is read from the EpochSource again
+	TOKEN_EPOCH_CACHE_TTL time.Duration = time.Minute
+)
+
+var (
+	// ErrTokenNotFound is returned by a TokenStore for tokens that are not live,
+	// either because they expired or because they were retired
+	ErrTokenNotFound = errors.New("token not found")
+	// ErrEpochNotFound is returned by a TokenStore for token generations it does
+	// not hold, which says nothing about the generation itself
+	ErrEpochNotFound = errors.New("token epoch not found")
+	// ErrUserNotFound is returned by an EpochSource for users that do not exist
+	ErrUserNotFound = errors.New("user not found")
+)
+
+type (
+	// TokenRecord is what the token store keeps about a live token
+	TokenRecord struct {
+		Type      string `json:"typ"`
+		UserID    string `json:"uid,omitempty"`
+		ClientID  string `json:"azp,omitempty"`
+		SessionID string `json:"sid,omitempty"`
+		FamilyID  string `json:"fid,omitempty"`
+		ExpiresAt int64  `json:"exp"`
+	}
+
+	// TokenStore holds the state of issued tokens: which of them are live and which
+	// sessions, families and users had their tokens revoked. Tokens are stored under
+	// their jti rather than the signed token, so keys stay short however many claims
+	// a token carries.
+	TokenStore interface {
+		// Save registers a token as live until the record expires
+		Save(jti string, record TokenRecord) error
+		// Get returns the record of a live token, or ErrTokenNotFound
+		Get(jti string) (TokenRecord, error)
+		// Delete retires a live token. Of concurrent deletes of the same token only
+		// one succeeds, the others get ErrTokenNotFound.
+		Delete(jti string) error
+		// Mark flags an id of the given kind until the given time
+		Mark(kind, id string, until time.Time) error
+		// Marked reports whether an id of the given kind is flagged
+		Marked(kind, id string) (bool, error)
+		// Epoch returns the cached token generation of a user, or ErrEpochNotFound
+		Epoch(userID string) (int64, error)
+		// CacheEpoch caches the token generation of a user until the given time. A
+		// lower generation than the cached one is ignored, so a stale read can never
+		// undo a revocation.
+		CacheEpoch(userID string, epoch int64, until time.Time) error
+	}
+
+	// EpochSource keeps the token generations of users for good. Token stores may
+	// evict them, so a generation missing from the store is read from here rather
+	// than taken for 0.
+	EpochSource interface {
+		// TokenEpoch returns the token generation of a user, 0 for users that never
+		// had their tokens revoked, or ErrUserNotFound
+		TokenEpoch(ctx context.Context, userID string) (int64, error)
+		// NextTokenEpoch moves a user on to the next token generation and returns it
+		NextTokenEpoch(ctx context.Context, userID string) (int64, error)
+	}
+)
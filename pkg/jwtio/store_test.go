@@ -0,0 +1,179 @@
+package jwtio_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/digisata/auth-service/pkg/cache"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/memcached"
+	"github.com/digisata/auth-service/pkg/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTokenStore runs the tests every token store has to pass, advance moves the
+// clock of the store forward
+func testTokenStore(t *testing.T, store jwtio.TokenStore, advance func(d time.Duration)) {
+	prefix := fmt.Sprintf("storetest-%d-", time.Now().UnixNano())
+
+	t.Run("Tokens", func(t *testing.T) {
+		jti := prefix + "token"
+		record := jwtio.TokenRecord{
+			Type:      jwtio.TOKEN_TYPE_REFRESH,
+			UserID:    "user-1",
+			SessionID: "session-1",
+			FamilyID:  "family-1",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		}
+
+		_, err := store.Get(jti)
+		assert.ErrorIs(t, err, jwtio.ErrTokenNotFound)
+
+		require.NoError(t, store.Save(jti, record))
+
+		got, err := store.Get(jti)
+		require.NoError(t, err)
+		assert.Equal(t, record, got)
+
+		require.NoError(t, store.Delete(jti))
+		assert.ErrorIs(t, store.Delete(jti), jwtio.ErrTokenNotFound)
+
+		_, err = store.Get(jti)
+		assert.ErrorIs(t, err, jwtio.ErrTokenNotFound)
+	})
+
+	t.Run("Marks", func(t *testing.T) {
+		id := prefix + "session"
+
+		marked, err := store.Marked(jwtio.MARK_REVOKED_SESSION, id)
+		require.NoError(t, err)
+		assert.False(t, marked)
+
+		require.NoError(t, store.Mark(jwtio.MARK_REVOKED_SESSION, id, time.Now().Add(time.Hour)))
+
+		marked, err = store.Marked(jwtio.MARK_REVOKED_SESSION, id)
+		require.NoError(t, err)
+		assert.True(t, marked)
+
+		// Kinds don't share ids
+		marked, err = store.Marked(jwtio.MARK_REVOKED_FAMILY, id)
+		require.NoError(t, err)
+		assert.False(t, marked)
+	})
+
+	t.Run("Epochs", func(t *testing.T) {
+		userID := prefix + "user"
+		until := time.Now().Add(time.Hour)
+
+		_, err := store.Epoch(userID)
+		assert.ErrorIs(t, err, jwtio.ErrEpochNotFound)
+
+		require.NoError(t, store.CacheEpoch(userID, 2, until))
+
+		epoch, err := store.Epoch(userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), epoch)
+
+		// A stale read of an older generation does not replace the cached one
+		require.NoError(t, store.CacheEpoch(userID, 1, until))
+
+		epoch, err = store.Epoch(userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), epoch)
+
+		require.NoError(t, store.CacheEpoch(userID, 3, until))
+
+		epoch, err = store.Epoch(userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), epoch)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		jti := prefix + "expiring"
+		until := time.Now().Add(time.Second)
+
+		require.NoError(t, store.Save(jti, jwtio.TokenRecord{Type: jwtio.TOKEN_TYPE_ACCESS, ExpiresAt: until.Unix()}))
+		require.NoError(t, store.Mark(jwtio.MARK_ROTATED, jti, until))
+		require.NoError(t, store.CacheEpoch(jti, 1, until))
+
+		advance(2 * time.Second)
+
+		_, err := store.Get(jti)
+		assert.ErrorIs(t, err, jwtio.ErrTokenNotFound)
+
+		marked, err := store.Marked(jwtio.MARK_ROTATED, jti)
+		require.NoError(t, err)
+		assert.False(t, marked)
+
+		_, err = store.Epoch(jti)
+		assert.ErrorIs(t, err, jwtio.ErrEpochNotFound)
+	})
+}
+
+func TestMemoryTokenStore(t *testing.T) {
+	now := time.Now()
+	memory := cache.NewMemory(cache.Config{}, func() time.Time { return now })
+	defer memory.Close()
+
+	testTokenStore(t, jwtio.NewMemoryTokenStore(memory), func(d time.Duration) {
+		now = now.Add(d)
+	})
+}
+
+// A full cache evicts live tokens, never the marks revoking sessions and families
+func TestMemoryTokenStoreKeepsMarks(t *testing.T) {
+	memory := cache.NewMemory(cache.Config{MaxEntries: 10}, nil)
+	defer memory.Close()
+
+	store := jwtio.NewMemoryTokenStore(memory)
+	until := time.Now().Add(time.Hour)
+
+	require.NoError(t, store.Mark(jwtio.MARK_REVOKED_SESSION, "session-1", until))
+	require.NoError(t, store.Mark(jwtio.MARK_REVOKED_FAMILY, "family-1", until))
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, store.Save(strconv.Itoa(i), jwtio.TokenRecord{ExpiresAt: until.Unix()}))
+	}
+
+	marked, err := store.Marked(jwtio.MARK_REVOKED_SESSION, "session-1")
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	marked, err = store.Marked(jwtio.MARK_REVOKED_FAMILY, "family-1")
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	_, err = store.Get("0")
+	assert.ErrorIs(t, err, jwtio.ErrTokenNotFound)
+}
+
+func TestRedisTokenStore(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	db, err := redis.NewDatabase(redis.Config{Addrs: []string{server.Addr()}})
+	require.NoError(t, err)
+	defer db.Close()
+
+	testTokenStore(t, jwtio.NewRedisTokenStore(db), server.FastForward)
+}
+
+// TestMemcachedTokenStore needs a memcached server at MEMCACHED_ADDR
+func TestMemcachedTokenStore(t *testing.T) {
+	addr := os.Getenv("MEMCACHED_ADDR")
+	if addr == "" {
+		t.Skip("MEMCACHED_ADDR is not set")
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	db := memcached.NewDatabase(memcached.Config{DBHost: host, DBPort: port})
+
+	testTokenStore(t, jwtio.NewMemcachedTokenStore(db), time.Sleep)
+}
@@ -0,0 +1,65 @@
+// Package lockout is shared pkg of failed login throttling
+package lockout
+
+import (
+	"math"
+	"time"
+)
+
+type Config struct {
+	// MaxAttempts locks the account for everyone, MaxAttemptsPerIP only locks the
+	// account for the address the failures came from
+	MaxAttempts      int `mapstructure:"MAX_ATTEMPTS"`
+	MaxAttemptsPerIP int `mapstructure:"MAX_ATTEMPTS_PER_IP"`
+	WindowSecond     int `mapstructure:"WINDOW_SECOND"`
+	LockSecond       int `mapstructure:"LOCK_SECOND"`
+	MaxLockSecond    int `mapstructure:"MAX_LOCK_SECOND"`
+	// ResetHour is how long a lockout keeps counting towards the next lock duration
+	ResetHour int `mapstructure:"RESET_HOUR"`
+}
+
+func (cfg Config) Window() time.Duration {
+	return time.Second * time.Duration(cfg.WindowSecond)
+}
+
+// LockDuration doubles the lock for every lockout since the last reset, capped
+// at MaxLockSecond. An unset MaxLockSecond leaves the lock uncapped.
+func (cfg Config) LockDuration(lockouts int64) time.Duration {
+	lock := time.Second * time.Duration(cfg.LockSecond)
+	max := time.Duration(math.MaxInt64)
+
+	if cfg.MaxLockSecond > 0 {
+		max = time.Second * time.Duration(cfg.MaxLockSecond)
+	}
+
+	for i := int64(1); i < lockouts && lock < max; i++ {
+		// Doubling past half of max would overflow before the cap is applied
+		if lock > max/2 {
+			lock = max
+			break
+		}
+
+		lock *= 2
+	}
+
+	if lock > max {
+		lock = max
+	}
+
+	return lock
+}
+
+// WindowIndex returns the fixed window now falls in and how far into it now is
+func (cfg Config) WindowIndex(now time.Time) (int64, float64) {
+	window := int64(cfg.WindowSecond)
+	unix := now.Unix()
+
+	return unix / window, float64(unix%window) / float64(window)
+}
+
+// SlidingCount estimates the failures within the last window from the counts of
+// the current and the previous fixed window, weighting the previous one by how
+// much of it still overlaps the sliding window
+func SlidingCount(previous, current int64, elapsed float64) float64 {
+	return float64(previous)*(1-elapsed) + float64(current)
+}
@@ -0,0 +1,35 @@
+package lockout_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/lockout"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockDuration(t *testing.T) {
+	cfg := lockout.Config{
+		LockSecond:    60,
+		MaxLockSecond: 600,
+	}
+
+	assert.Equal(t, time.Minute, cfg.LockDuration(1))
+	assert.Equal(t, 2*time.Minute, cfg.LockDuration(2))
+	assert.Equal(t, 8*time.Minute, cfg.LockDuration(4))
+	assert.Equal(t, 10*time.Minute, cfg.LockDuration(5))
+	assert.Equal(t, 10*time.Minute, cfg.LockDuration(100))
+
+	// Without a cap the lock keeps doubling
+	cfg.MaxLockSecond = 0
+	assert.Equal(t, time.Minute, cfg.LockDuration(1))
+	assert.Equal(t, 16*time.Minute, cfg.LockDuration(5))
+	assert.Equal(t, time.Duration(math.MaxInt64), cfg.LockDuration(100))
+}
+
+func TestSlidingCount(t *testing.T) {
+	assert.Equal(t, 7.0, lockout.SlidingCount(4, 3, 0))
+	assert.Equal(t, 5.0, lockout.SlidingCount(4, 3, 0.5))
+	assert.Equal(t, 3.0, lockout.SlidingCount(4, 3, 1))
+}
@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirMailer writes every message as an .eml file into a directory instead of
+// sending it, so local runs and tests can read the outgoing mail
+type DirMailer struct {
+	cfg Config
+}
+
+func NewDirMailer(cfg Config) (*DirMailer, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("mailer dir is required")
+	}
+
+	err := os.MkdirAll(cfg.Dir, 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DirMailer{
+		cfg: cfg,
+	}, nil
+}
+
+func (m DirMailer) Send(ctx context.Context, msg Message) error {
+	err := validHeader(msg.To, msg.Subject)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%d-%s.eml", now.UnixNano(), filepath.Base(msg.To))
+
+	return os.WriteFile(filepath.Join(m.cfg.Dir, name), format(m.cfg.From, msg, now), 0o600)
+}
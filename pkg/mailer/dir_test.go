@@ -0,0 +1,48 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirMailerSend(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := mailer.NewDirMailer(mailer.Config{
+		From: "no-reply@example.com",
+		Dir:  dir,
+	})
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		err := m.Send(context.Background(), mailer.Message{
+			To:      "test@gmail.com",
+			Subject: "Verify your email address",
+			Body:    "Hi Test",
+		})
+
+		assert.NoError(t, err)
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+		assert.Len(t, files, 1)
+
+		content, _ := os.ReadFile(files[0])
+		assert.Contains(t, string(content), "To: test@gmail.com\r\n")
+		assert.Contains(t, string(content), "Subject: Verify your email address\r\n")
+		assert.Contains(t, string(content), "\r\n\r\nHi Test")
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := m.Send(context.Background(), mailer.Message{
+			To:      "test@gmail.com\r\nBcc: other@gmail.com",
+			Subject: "Verify your email address",
+		})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+// Package mailer is shared pkg of outgoing email
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DRIVER_SMTP string = "smtp"
+	DRIVER_DIR  string = "dir"
+)
+
+type (
+	Config struct {
+		Driver string     `mapstructure:"DRIVER"`
+		From   string     `mapstructure:"FROM"`
+		Dir    string     `mapstructure:"DIR"`
+		SMTP   SMTPConfig `mapstructure:"SMTP"`
+		// LinkBaseURL is the frontend that emailed links point to
+		LinkBaseURL               string `mapstructure:"LINK_BASE_URL"`
+		VerificationExpiryMinute  int    `mapstructure:"VERIFICATION_EXPIRY_MINUTE"`
+		PasswordResetExpiryMinute int    `mapstructure:"PASSWORD_RESET_EXPIRY_MINUTE"`
+		MagicLinkExpiryMinute     int    `mapstructure:"MAGIC_LINK_EXPIRY_MINUTE"`
+		// MagicLinkRoles are the names of the roles that may sign in by magic link
+		MagicLinkRoles []string `mapstructure:"MAGIC_LINK_ROLES"`
+	}
+
+	SMTPConfig struct {
+		Host     string `mapstructure:"HOST"`
+		Port     string `mapstructure:"PORT"`
+		Username string `mapstructure:"USERNAME"`
+		Password string `mapstructure:"PASSWORD"`
+	}
+
+	Message struct {
+		To      string
+		Subject string
+		Body    string
+	}
+
+	// Mailer sends an email, implementations decide where it ends up
+	Mailer interface {
+		Send(ctx context.Context, msg Message) error
+	}
+)
+
+// NewMailer returns the mailer selected by cfg.Driver
+func NewMailer(cfg Config) (Mailer, error) {
+	switch cfg.Driver {
+	case DRIVER_SMTP:
+		return NewSMTPMailer(cfg)
+	case DRIVER_DIR, "":
+		return NewDirMailer(cfg)
+	}
+
+	return nil, fmt.Errorf("unknown mailer driver: %s", cfg.Driver)
+}
+
+// format renders msg as a plain text RFC 5322 message
+func format(from string, msg Message, now time.Time) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
+
+// validHeader refuses values that could inject extra headers
+func validHeader(values ...string) error {
+	for _, val := range values {
+		if strings.ContainsAny(val, "\r\n") {
+			return fmt.Errorf("invalid mail header value")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPMailer delivers mail to an SMTP server, authenticating with PLAIN auth when
+// a username is configured
+type SMTPMailer struct {
+	cfg Config
+	// sender is the bare address of cfg.From, the display name only goes in the
+	// From header
+	sender string
+}
+
+func NewSMTPMailer(cfg Config) (*SMTPMailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mailer from address %q: %w", cfg.From, err)
+	}
+
+	return &SMTPMailer{
+		cfg:    cfg,
+		sender: from.Address,
+	}, nil
+}
+
+func (m SMTPMailer) Send(ctx context.Context, msg Message) error {
+	err := validHeader(msg.To, msg.Subject)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.SMTP.Username, m.cfg.SMTP.Password, m.cfg.SMTP.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.SMTP.Host, m.cfg.SMTP.Port)
+	done := make(chan error, 1)
+
+	// net/smtp has no context support, give up waiting once ctx is done
+	go func() {
+		done <- smtp.SendMail(addr, auth, m.sender, []string{msg.To}, format(m.cfg.From, msg, time.Now()))
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,104 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveSMTP accepts one delivery and returns the commands and the data it got
+func serveSMTP(t *testing.T) (string, <-chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var lines []string
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				break
+			}
+
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case line == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil || data == ".\r\n" {
+						break
+					}
+					lines = append(lines, strings.TrimRight(data, "\r\n"))
+				}
+				reply("250 queued")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+
+		received <- lines
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	addr, received := serveSMTP(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	m, err := mailer.NewSMTPMailer(mailer.Config{
+		From: "Auth Service <no-reply@example.com>",
+		SMTP: mailer.SMTPConfig{Host: host, Port: port},
+	})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), mailer.Message{
+		To:      "test@gmail.com",
+		Subject: "Verify your email address",
+		Body:    "Hi Test",
+	})
+	require.NoError(t, err)
+
+	lines := <-received
+
+	// The envelope carries the bare address, the header keeps the display name
+	assert.Contains(t, lines, "MAIL FROM:<no-reply@example.com>")
+	assert.Contains(t, lines, "RCPT TO:<test@gmail.com>")
+	assert.Contains(t, lines, "From: Auth Service <no-reply@example.com>")
+}
+
+func TestNewSMTPMailerRejectsInvalidFrom(t *testing.T) {
+	_, err := mailer.NewSMTPMailer(mailer.Config{From: "Auth Service"})
+	assert.Error(t, err)
+
+	_, err = mailer.NewMailer(mailer.Config{Driver: mailer.DRIVER_SMTP})
+	assert.Error(t, err)
+}
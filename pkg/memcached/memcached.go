@@ -46,3 +46,28 @@ func (db Database) Delete(key string) error {
 
 	return nil
 }
+
+func (db Database) Add(req *memcache.Item) error {
+	if err := db.Mc.Add(req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (db Database) Increment(key string, delta uint64) (uint64, error) {
+	val, err := db.Mc.Increment(key, delta)
+	if err != nil {
+		return 0, err
+	}
+
+	return val, nil
+}
+
+func (db Database) CompareAndSwap(req *memcache.Item) error {
+	if err := db.Mc.CompareAndSwap(req); err != nil {
+		return err
+	}
+
+	return nil
+}
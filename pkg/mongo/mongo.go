@@ -37,6 +37,7 @@ type Collection interface {
 	Aggregate(context.Context, interface{}) (Cursor, error)
 	UpdateOne(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	UpdateMany(context.Context, interface{}, interface{}, ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	CreateIndexes(context.Context, []mongo.IndexModel) ([]string, error)
 }
 
 type SingleResult interface {
@@ -185,6 +186,10 @@ func (mc *mongoCollection) UpdateMany(ctx context.Context, filter interface{}, u
 	return mc.coll.UpdateMany(ctx, filter, update, opts[:]...)
 }
 
+func (mc *mongoCollection) CreateIndexes(ctx context.Context, models []mongo.IndexModel) ([]string, error) {
+	return mc.coll.Indexes().CreateMany(ctx, models)
+}
+
 func (mc *mongoCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
 	return mc.coll.CountDocuments(ctx, filter, opts...)
 }
@@ -0,0 +1,31 @@
+// Package oauth is shared pkg of oauth2 helpers
+package oauth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+type (
+	Config struct {
+		AuthorizationCodeExpirySecond int `mapstructure:"AUTHORIZATION_CODE_EXPIRY_SECOND"`
+	}
+)
+
+// GenerateClientID returns a random, non secret client identifier
+func GenerateClientID() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// ParseScope splits a space delimited scope parameter (RFC 6749 section 3.3)
+func ParseScope(scope string) []string {
+	return strings.Fields(scope)
+}
@@ -0,0 +1,35 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"regexp"
+)
+
+const CODE_CHALLENGE_METHOD_S256 string = "S256"
+
+// RFC 7636 section 4.1, 43 to 128 unreserved characters
+var codeVerifierPattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// IsValidCodeChallenge checks the shape of a S256 code challenge, the base64url
+// encoding of a SHA-256 digest without padding
+func IsValidCodeChallenge(challenge string) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(challenge)
+
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// VerifyCodeChallenge checks a PKCE code verifier against the challenge sent to
+// the authorization endpoint. Only S256 is accepted, plain gives no protection
+// against an intercepted authorization request.
+func VerifyCodeChallenge(verifier, challenge, method string) bool {
+	if method != CODE_CHALLENGE_METHOD_S256 || !codeVerifierPattern.MatchString(verifier) {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
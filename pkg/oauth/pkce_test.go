@@ -0,0 +1,48 @@
+package oauth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 7636 appendix B
+const (
+	verifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestIsValidCodeChallenge(t *testing.T) {
+	assert.True(t, oauth.IsValidCodeChallenge(challenge))
+
+	assert.False(t, oauth.IsValidCodeChallenge(""))
+	assert.False(t, oauth.IsValidCodeChallenge(verifier[:20]))
+	assert.False(t, oauth.IsValidCodeChallenge(challenge+"="))
+	assert.False(t, oauth.IsValidCodeChallenge(strings.ReplaceAll(challenge, "-", "+")))
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.True(t, oauth.VerifyCodeChallenge(verifier, challenge, oauth.CODE_CHALLENGE_METHOD_S256))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tests := map[string]struct {
+			verifier, challenge, method string
+		}{
+			"plain method":       {verifier, verifier, "plain"},
+			"missing method":     {verifier, challenge, ""},
+			"wrong verifier":     {strings.Replace(verifier, "d", "e", 1), challenge, oauth.CODE_CHALLENGE_METHOD_S256},
+			"short verifier":     {verifier[:42], challenge, oauth.CODE_CHALLENGE_METHOD_S256},
+			"too long verifier":  {strings.Repeat("a", 129), challenge, oauth.CODE_CHALLENGE_METHOD_S256},
+			"reserved character": {verifier[:42] + "/", challenge, oauth.CODE_CHALLENGE_METHOD_S256},
+			"missing verifier":   {"", challenge, oauth.CODE_CHALLENGE_METHOD_S256},
+		}
+
+		for name, tt := range tests {
+			assert.False(t, oauth.VerifyCodeChallenge(tt.verifier, tt.challenge, tt.method), name)
+		}
+	})
+}
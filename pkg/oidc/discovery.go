@@ -0,0 +1,64 @@
+// Package oidc is shared pkg of openid connect provider metadata
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+type (
+	// Discovery is the OpenID Provider Metadata served on /.well-known/openid-configuration
+	Discovery struct {
+		Issuer                            string   `json:"issuer"`
+		AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+		TokenEndpoint                     string   `json:"token_endpoint"`
+		JwksURI                           string   `json:"jwks_uri"`
+		UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+		IntrospectionEndpoint             string   `json:"introspection_endpoint"`
+		ResponseTypesSupported            []string `json:"response_types_supported"`
+		GrantTypesSupported               []string `json:"grant_types_supported"`
+		CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+		SubjectTypesSupported             []string `json:"subject_types_supported"`
+		IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+		ScopesSupported                   []string `json:"scopes_supported"`
+		ClaimsSupported                   []string `json:"claims_supported"`
+		TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	}
+)
+
+func NewDiscovery(issuer string, signingAlgs []string) Discovery {
+	issuer = strings.TrimSuffix(issuer, "/")
+
+	return Discovery{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/api/v1/authorize",
+		TokenEndpoint:                     issuer + "/api/v1/token",
+		JwksURI:                           issuer + "/.well-known/jwks.json",
+		UserinfoEndpoint:                  issuer + "/api/v1/userinfo",
+		IntrospectionEndpoint:             issuer + "/api/v1/introspect",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "client_credentials"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  signingAlgs,
+		ScopesSupported:                   []string{"openid", "profile", "email"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "name", "email", "email_verified"},
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_basic", "client_secret_post"},
+	}
+}
+
+// NewDiscoveryHandler serves the discovery document. The signing algorithms are
+// resolved per request so the document follows signing key rotations.
+func NewDiscoveryHandler(issuer string, signingAlgs func() []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		json.NewEncoder(w).Encode(NewDiscovery(issuer, signingAlgs()))
+	})
+}
@@ -0,0 +1,224 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ALGORITHM_ARGON2ID = "argon2id"
+	ALGORITHM_BCRYPT   = "bcrypt"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+var ErrUnknownHash = errors.New("unknown password hash format")
+
+type (
+	HashConfig struct {
+		Algorithm  string `mapstructure:"ALGORITHM"`
+		BcryptCost int    `mapstructure:"BCRYPT_COST"`
+		// Argon2MemoryKiB is the memory per hash in KiB
+		Argon2MemoryKiB   uint32 `mapstructure:"ARGON2_MEMORY_KIB"`
+		Argon2Time        uint32 `mapstructure:"ARGON2_TIME"`
+		Argon2Parallelism uint8  `mapstructure:"ARGON2_PARALLELISM"`
+	}
+
+	// Hasher hashes new passwords with the configured algorithm and verifies
+	// hashes of every supported algorithm. Argon2id hashes are PHC strings,
+	// bcrypt hashes keep their own $2a$ format, both record their parameters.
+	Hasher struct {
+		cfg   HashConfig
+		dummy string
+	}
+
+	argon2Params struct {
+		memory      uint32
+		time        uint32
+		parallelism uint8
+		keyLength   uint32
+	}
+)
+
+func NewHasher(cfg HashConfig) (*Hasher, error) {
+	if cfg.Algorithm == "" {
+		cfg.Algorithm = ALGORITHM_BCRYPT
+	}
+
+	if cfg.BcryptCost == 0 {
+		cfg.BcryptCost = bcrypt.DefaultCost
+	}
+
+	switch cfg.Algorithm {
+	case ALGORITHM_BCRYPT:
+		if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case ALGORITHM_ARGON2ID:
+		if cfg.Argon2MemoryKiB == 0 || cfg.Argon2Time == 0 || cfg.Argon2Parallelism == 0 {
+			return nil, fmt.Errorf("argon2id memory, time and parallelism are required")
+		}
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm: %s", cfg.Algorithm)
+	}
+
+	h := &Hasher{
+		cfg: cfg,
+	}
+
+	dummy, err := h.Hash("dummy password")
+	if err != nil {
+		return nil, err
+	}
+
+	h.dummy = dummy
+
+	return h, nil
+}
+
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.cfg.Algorithm == ALGORITHM_ARGON2ID {
+		return h.hashArgon2id(password)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// Verify reports whether password matches hash, whichever algorithm made it
+func (h *Hasher) Verify(hash, password string) (bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+
+		other := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.parallelism, params.keyLength)
+
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+	case isBcrypt(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	default:
+		return false, ErrUnknownHash
+	}
+}
+
+// VerifyDummy spends as long as verifying a real hash, for callers that have no
+// hash to check against but must not tell so by responding faster
+func (h *Hasher) VerifyDummy(password string) {
+	h.Verify(h.dummy, password)
+}
+
+// NeedsRehash reports whether hash was made by another algorithm or with weaker
+// parameters than configured
+func (h *Hasher) NeedsRehash(hash string) bool {
+	switch h.cfg.Algorithm {
+	case ALGORITHM_ARGON2ID:
+		if !strings.HasPrefix(hash, "$argon2id$") {
+			return true
+		}
+
+		params, _, _, err := decodeArgon2id(hash)
+		if err != nil {
+			return true
+		}
+
+		return params.memory < h.cfg.Argon2MemoryKiB ||
+			params.time < h.cfg.Argon2Time ||
+			params.parallelism < h.cfg.Argon2Parallelism ||
+			params.keyLength < argon2KeyLength
+	default:
+		if !isBcrypt(hash) {
+			return true
+		}
+
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return true
+		}
+
+		return cost < h.cfg.BcryptCost
+	}
+}
+
+func (h *Hasher) hashArgon2id(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.cfg.Argon2Time, h.cfg.Argon2MemoryKiB, h.cfg.Argon2Parallelism, argon2KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.cfg.Argon2MemoryKiB,
+		h.cfg.Argon2Time,
+		h.cfg.Argon2Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// decodeArgon2id parses a PHC string like $argon2id$v=19$m=65536,t=3,p=2$salt$key
+func decodeArgon2id(hash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version: %s", parts[2])
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.parallelism)
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	params.keyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
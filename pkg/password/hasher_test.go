@@ -0,0 +1,63 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasher(t *testing.T) {
+	argon2id, err := password.NewHasher(password.HashConfig{
+		Algorithm:         password.ALGORITHM_ARGON2ID,
+		Argon2MemoryKiB:   1024,
+		Argon2Time:        1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err)
+
+	bcrypt, err := password.NewHasher(password.HashConfig{
+		Algorithm:  password.ALGORITHM_BCRYPT,
+		BcryptCost: 4,
+	})
+	require.NoError(t, err)
+
+	argon2Hash, err := argon2id.Hash("secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(argon2Hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	bcryptHash, err := bcrypt.Hash("secret")
+	require.NoError(t, err)
+
+	// Either hasher verifies the hashes of both algorithms
+	for _, h := range []*password.Hasher{argon2id, bcrypt} {
+		for _, hash := range []string{argon2Hash, bcryptHash} {
+			ok, err := h.Verify(hash, "secret")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = h.Verify(hash, "wrong")
+			assert.NoError(t, err)
+			assert.False(t, ok)
+		}
+	}
+
+	_, err = argon2id.Verify("plain", "secret")
+	assert.ErrorIs(t, err, password.ErrUnknownHash)
+
+	assert.False(t, argon2id.NeedsRehash(argon2Hash))
+	assert.True(t, argon2id.NeedsRehash(bcryptHash))
+	assert.True(t, bcrypt.NeedsRehash(argon2Hash))
+	assert.False(t, bcrypt.NeedsRehash(bcryptHash))
+
+	stronger, err := password.NewHasher(password.HashConfig{
+		Algorithm:         password.ALGORITHM_ARGON2ID,
+		Argon2MemoryKiB:   2048,
+		Argon2Time:        1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err)
+	assert.True(t, stronger.NeedsRehash(argon2Hash))
+}
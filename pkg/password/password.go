@@ -0,0 +1,210 @@
+// Package password is shared pkg of password policy checks
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MAX_LENGTH is the most bcrypt hashes, longer passwords would be silently cut
+const MAX_LENGTH = 72
+
+const (
+	RULE_MIN_LENGTH    = "min_length"
+	RULE_MAX_LENGTH    = "max_length"
+	RULE_UPPERCASE     = "uppercase"
+	RULE_LOWERCASE     = "lowercase"
+	RULE_DIGIT         = "digit"
+	RULE_SYMBOL        = "symbol"
+	RULE_PERSONAL_INFO = "personal_info"
+	RULE_BREACHED      = "breached"
+)
+
+// personalInfoMinLength skips name parts too short to be worth rejecting
+const personalInfoMinLength = 3
+
+type (
+	Config struct {
+		MinLength     int  `mapstructure:"MIN_LENGTH"`
+		MaxLength     int  `mapstructure:"MAX_LENGTH"`
+		RequireUpper  bool `mapstructure:"REQUIRE_UPPER"`
+		RequireLower  bool `mapstructure:"REQUIRE_LOWER"`
+		RequireDigit  bool `mapstructure:"REQUIRE_DIGIT"`
+		RequireSymbol bool `mapstructure:"REQUIRE_SYMBOL"`
+		// BreachedListFile holds one known breached password per line
+		BreachedListFile string `mapstructure:"BREACHED_LIST_FILE"`
+		// HistoryDepth is per role name how many of the latest passwords, the
+		// current one included, can't be used again. Zero allows any reuse.
+		HistoryDepth map[string]int `mapstructure:"HISTORY_DEPTH"`
+		Hashing      HashConfig     `mapstructure:"HASHING"`
+	}
+
+	Violation struct {
+		Rule        string
+		Description string
+	}
+
+	Policy struct {
+		cfg      Config
+		breached map[string]struct{}
+	}
+)
+
+// HistoryDepthOf returns the history depth of a role, matched case insensitively
+// since viper lowercases map keys
+func (cfg Config) HistoryDepthOf(role string) int {
+	for name, depth := range cfg.HistoryDepth {
+		if strings.EqualFold(name, role) {
+			return depth
+		}
+	}
+
+	return 0
+}
+
+func NewPolicy(cfg Config) (*Policy, error) {
+	if cfg.MaxLength == 0 {
+		cfg.MaxLength = MAX_LENGTH
+	}
+
+	if cfg.MaxLength > MAX_LENGTH {
+		return nil, fmt.Errorf("password max length can't exceed %d bytes", MAX_LENGTH)
+	}
+
+	if cfg.MinLength < 1 {
+		cfg.MinLength = 1
+	}
+
+	if cfg.MinLength > cfg.MaxLength {
+		return nil, fmt.Errorf("password min length %d exceeds max length %d", cfg.MinLength, cfg.MaxLength)
+	}
+
+	policy := &Policy{
+		cfg:      cfg,
+		breached: make(map[string]struct{}),
+	}
+
+	if cfg.BreachedListFile != "" {
+		err := policy.loadBreached(cfg.BreachedListFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return policy, nil
+}
+
+func (p *Policy) loadBreached(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open breached password list: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		p.breached[strings.ToLower(line)] = struct{}{}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return fmt.Errorf("can't read breached password list: %w", err)
+	}
+
+	return nil
+}
+
+// Check returns every rule password breaks, name and email are of the user the
+// password is for and may be empty
+func (p *Policy) Check(password, name, email string) []Violation {
+	var violations []Violation
+
+	// Lengths are in bytes, which is what bcrypt limits
+	if len(password) < p.cfg.MinLength {
+		violations = append(violations, Violation{
+			Rule:        RULE_MIN_LENGTH,
+			Description: fmt.Sprintf("Must be at least %d characters long", p.cfg.MinLength),
+		})
+	}
+
+	if len(password) > p.cfg.MaxLength {
+		violations = append(violations, Violation{
+			Rule:        RULE_MAX_LENGTH,
+			Description: fmt.Sprintf("Must be at most %d bytes long", p.cfg.MaxLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.cfg.RequireUpper && !hasUpper {
+		violations = append(violations, Violation{Rule: RULE_UPPERCASE, Description: "Must contain an uppercase letter"})
+	}
+
+	if p.cfg.RequireLower && !hasLower {
+		violations = append(violations, Violation{Rule: RULE_LOWERCASE, Description: "Must contain a lowercase letter"})
+	}
+
+	if p.cfg.RequireDigit && !hasDigit {
+		violations = append(violations, Violation{Rule: RULE_DIGIT, Description: "Must contain a digit"})
+	}
+
+	if p.cfg.RequireSymbol && !hasSymbol {
+		violations = append(violations, Violation{Rule: RULE_SYMBOL, Description: "Must contain a symbol"})
+	}
+
+	lower := strings.ToLower(password)
+
+	if containsPersonalInfo(lower, name, email) {
+		violations = append(violations, Violation{Rule: RULE_PERSONAL_INFO, Description: "Must not contain your name or email"})
+	}
+
+	if _, ok := p.breached[lower]; ok {
+		violations = append(violations, Violation{Rule: RULE_BREACHED, Description: "Appears in a list of breached passwords"})
+	}
+
+	return violations
+}
+
+// containsPersonalInfo checks the lowercased password for the parts of the name
+// and the local part of the email
+func containsPersonalInfo(password, name, email string) bool {
+	parts := strings.Fields(strings.ToLower(name))
+
+	email = strings.ToLower(email)
+	if local, _, ok := strings.Cut(email, "@"); ok {
+		parts = append(parts, local)
+	}
+
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) < personalInfoMinLength {
+			continue
+		}
+
+		if strings.Contains(password, part) {
+			return true
+		}
+	}
+
+	return false
+}
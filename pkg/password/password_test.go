@@ -0,0 +1,63 @@
+package password_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rules(violations []password.Violation) []string {
+	var res []string
+	for _, v := range violations {
+		res = append(res, v.Rule)
+	}
+
+	return res
+}
+
+func TestCheck(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(file, []byte("# common\nCorrectHorse1!\n"), 0o600)
+	require.NoError(t, err)
+
+	policy, err := password.NewPolicy(password.Config{
+		MinLength:        8,
+		RequireUpper:     true,
+		RequireLower:     true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		BreachedListFile: file,
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, policy.Check("Tr0ub4dor&3", "Jane Doe", "jane@example.com"))
+	assert.Equal(t, []string{password.RULE_MIN_LENGTH, password.RULE_UPPERCASE, password.RULE_DIGIT, password.RULE_SYMBOL}, rules(policy.Check("abc", "", "")))
+	assert.Equal(t, []string{password.RULE_MAX_LENGTH}, rules(policy.Check("Aa1!"+strings.Repeat("x", 70), "", "")))
+	assert.Equal(t, []string{password.RULE_PERSONAL_INFO}, rules(policy.Check("Janedoe#2024", "Jane Doe", "")))
+	assert.Equal(t, []string{password.RULE_PERSONAL_INFO}, rules(policy.Check("X-jdoe-2024!", "Jo", "jdoe@example.com")))
+	assert.Equal(t, []string{password.RULE_BREACHED}, rules(policy.Check("CorrectHorse1!", "", "")))
+	assert.Equal(t, []string{password.RULE_BREACHED}, rules(policy.Check("correctHORSE1!", "", "")))
+}
+
+func TestNewPolicy(t *testing.T) {
+	_, err := password.NewPolicy(password.Config{MaxLength: 100})
+	assert.Error(t, err)
+
+	_, err = password.NewPolicy(password.Config{BreachedListFile: "missing.txt"})
+	assert.Error(t, err)
+}
+
+func TestHistoryDepthOf(t *testing.T) {
+	cfg := password.Config{
+		HistoryDepth: map[string]int{"admin": 10, "customer": 5},
+	}
+
+	assert.Equal(t, 10, cfg.HistoryDepthOf("Admin"))
+	assert.Equal(t, 5, cfg.HistoryDepthOf("customer"))
+	assert.Equal(t, 0, cfg.HistoryDepthOf("committee"))
+}
@@ -0,0 +1,54 @@
+// Package portal maps the portals users sign in to onto the roles allowed in
+package portal
+
+import (
+	"sort"
+	"strings"
+)
+
+type (
+	// Config lists the names of the roles allowed into each portal
+	Config map[string][]string
+
+	Portal struct {
+		Name  string
+		Roles []string
+	}
+)
+
+// Lookup returns a portal by name. Names are matched case insensitively since
+// viper lowercases map keys.
+func (cfg Config) Lookup(name string) (Portal, bool) {
+	for portal, roles := range cfg {
+		if strings.EqualFold(portal, name) {
+			return Portal{Name: portal, Roles: roles}, true
+		}
+	}
+
+	return Portal{}, false
+}
+
+// Admitting returns the names of the portals that allow the named role in
+func (cfg Config) Admitting(role string) []string {
+	var names []string
+	for name, roles := range cfg {
+		if (Portal{Name: name, Roles: roles}).Allows(role) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Allows reports whether users of the named role may sign in to the portal
+func (p Portal) Allows(role string) bool {
+	for _, r := range p.Roles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+
+	return false
+}
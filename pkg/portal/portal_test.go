@@ -0,0 +1,36 @@
+package portal_test
+
+import (
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/portal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	cfg := portal.Config{
+		"backoffice": {"admin", "Committee"},
+		"shop":       {"customer"},
+	}
+
+	p, ok := cfg.Lookup("BackOffice")
+	assert.True(t, ok)
+	assert.Equal(t, "backoffice", p.Name)
+	assert.True(t, p.Allows("admin"))
+	assert.True(t, p.Allows("committee"))
+	assert.False(t, p.Allows("customer"))
+
+	_, ok = cfg.Lookup("unknown")
+	assert.False(t, ok)
+}
+
+func TestAdmitting(t *testing.T) {
+	cfg := portal.Config{
+		"backoffice": {"admin", "Committee"},
+		"shop":       {"customer", "committee"},
+	}
+
+	assert.Equal(t, []string{"backoffice"}, cfg.Admitting("Admin"))
+	assert.Equal(t, []string{"backoffice", "shop"}, cfg.Admitting("committee"))
+	assert.Empty(t, cfg.Admitting("auditor"))
+}
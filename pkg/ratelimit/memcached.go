@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	"github.com/digisata/auth-service/pkg/memcached"
+)
+
+const (
+	KEY_PREFIX = "rate_limit:"
+
+	// casAttempts bounds how often a bucket is retried when other instances keep
+	// updating it in between
+	casAttempts = 5
+)
+
+// MemcachedLimiter keeps buckets in memcached, so the limit is shared by every
+// instance. Buckets are updated with compare and swap.
+type MemcachedLimiter struct {
+	db *memcached.Database
+}
+
+func NewMemcachedLimiter(db *memcached.Database) *MemcachedLimiter {
+	return &MemcachedLimiter{
+		db: db,
+	}
+}
+
+func (l MemcachedLimiter) Allow(key string, policy Policy, now time.Time) (bool, time.Duration, error) {
+	key = KEY_PREFIX + key
+
+	for i := 0; i < casAttempts; i++ {
+		var b bucket
+
+		item, err := l.db.Get(key)
+		if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+			return false, 0, err
+		}
+
+		if item != nil {
+			err = json.Unmarshal(item.Value, &b)
+			if err != nil {
+				return false, 0, err
+			}
+		}
+
+		next, allowed, retryAfter := b.take(policy, now)
+
+		value, err := json.Marshal(next)
+		if err != nil {
+			return false, 0, err
+		}
+
+		// The state expires once the bucket is full again
+		exp := int32(now.Add(next.idle(policy)).Unix()) + 1
+
+		if item == nil {
+			err = l.db.Add(&memcache.Item{Key: key, Value: value, Expiration: exp})
+		} else {
+			item.Value = value
+			item.Expiration = exp
+			err = l.db.CompareAndSwap(item)
+		}
+
+		if errors.Is(err, memcache.ErrNotStored) || errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrCacheMiss) {
+			continue
+		}
+
+		if err != nil {
+			return false, 0, err
+		}
+
+		return allowed, retryAfter, nil
+	}
+
+	return false, 0, fmt.Errorf("rate limit bucket %s is contended", key)
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type (
+	memoryBucket struct {
+		bucket
+		full time.Time
+	}
+
+	// MemoryLimiter keeps buckets in process, so every instance limits on its own
+	MemoryLimiter struct {
+		mu        sync.Mutex
+		buckets   map[string]*memoryBucket
+		lastSweep time.Time
+	}
+)
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+func (l *MemoryLimiter) Allow(key string, policy Policy, now time.Time) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &memoryBucket{}
+		l.buckets[key] = b
+	}
+
+	next, allowed, retryAfter := b.take(policy, now)
+	b.bucket = next
+	b.full = now.Add(next.idle(policy))
+
+	return allowed, retryAfter, nil
+}
+
+// sweep drops the buckets that have filled up again, a full bucket behaves the
+// same as a missing one
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		if !now.Before(b.full) {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,121 @@
+// Package ratelimit is shared pkg of token bucket request throttling
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/memcached"
+	"github.com/digisata/auth-service/pkg/redis"
+)
+
+const (
+	BACKEND_MEMORY    = "memory"
+	BACKEND_MEMCACHED = "memcached"
+	BACKEND_REDIS     = "redis"
+
+	KEY_IP   = "ip"
+	KEY_USER = "user"
+)
+
+type (
+	// Policy is a token bucket holding up to Burst requests, refilled with Rate
+	// requests per second. A zero rate disables the limit.
+	Policy struct {
+		Rate  float64 `mapstructure:"RATE"`
+		Burst int     `mapstructure:"BURST"`
+		// Key is what the bucket is per, the caller ip or the authenticated user.
+		// Unauthenticated calls always fall back to the ip.
+		Key string `mapstructure:"KEY"`
+	}
+
+	Config struct {
+		Backend string `mapstructure:"BACKEND"`
+		Default Policy `mapstructure:"DEFAULT"`
+		// Methods overrides the default policy per RPC name, e.g. LoginAdmin
+		Methods map[string]Policy `mapstructure:"METHODS"`
+	}
+
+	Limiter interface {
+		// Allow takes a token from the bucket of key, or tells how long until one
+		// is available
+		Allow(key string, policy Policy, now time.Time) (bool, time.Duration, error)
+	}
+)
+
+// NewLimiter builds the limiter of cfg.Backend. The shared backends need the
+// database of the matching cache driver, the other one is nil.
+func NewLimiter(cfg Config, memcachedDB *memcached.Database, redisDB *redis.Database) (Limiter, error) {
+	switch cfg.Backend {
+	case "", BACKEND_MEMORY:
+		return NewMemoryLimiter(), nil
+	case BACKEND_MEMCACHED:
+		if memcachedDB == nil {
+			return nil, fmt.Errorf("rate limit backend memcached needs the memcached cache driver")
+		}
+
+		return NewMemcachedLimiter(memcachedDB), nil
+	case BACKEND_REDIS:
+		if redisDB == nil {
+			return nil, fmt.Errorf("rate limit backend redis needs the redis cache driver")
+		}
+
+		return NewRedisLimiter(redisDB), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend: %s", cfg.Backend)
+	}
+}
+
+// Policy returns the policy of an RPC. Config keys are matched case
+// insensitively, since viper lowercases map keys.
+func (cfg Config) Policy(method string) Policy {
+	for name, policy := range cfg.Methods {
+		if strings.EqualFold(name, method) {
+			return policy
+		}
+	}
+
+	return cfg.Default
+}
+
+func (p Policy) Enabled() bool {
+	return p.Rate > 0 && p.Burst > 0
+}
+
+// bucket is the state of one token bucket, Last is when it was last refilled in
+// unix nanoseconds
+type bucket struct {
+	Tokens float64 `json:"tokens"`
+	Last   int64   `json:"last"`
+}
+
+// take refills the bucket for the time since it was last taken from and takes
+// one token out of it
+func (b bucket) take(policy Policy, now time.Time) (bucket, bool, time.Duration) {
+	burst := float64(policy.Burst)
+	tokens := burst
+
+	if b.Last != 0 {
+		elapsed := time.Duration(now.UnixNano() - b.Last).Seconds()
+		tokens = math.Min(burst, b.Tokens+math.Max(elapsed, 0)*policy.Rate)
+	}
+
+	next := bucket{Tokens: tokens, Last: now.UnixNano()}
+
+	if tokens < 1 {
+		retryAfter := time.Duration((1 - tokens) / policy.Rate * float64(time.Second))
+		return next, false, retryAfter
+	}
+
+	next.Tokens--
+
+	return next, true, 0
+}
+
+// idle returns how long until the bucket is full again, after which its state
+// can be dropped
+func (b bucket) idle(policy Policy) time.Duration {
+	return time.Duration((float64(policy.Burst) - b.Tokens) / policy.Rate * float64(time.Second))
+}
@@ -0,0 +1,107 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/digisata/auth-service/pkg/ratelimit"
+	"github.com/digisata/auth-service/pkg/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	policy := ratelimit.Policy{Rate: 1, Burst: 2}
+	now := time.Unix(1700000000, 0)
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := limiter.Allow("a", policy, now)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := limiter.Allow("a", policy, now)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// Another key has its own bucket
+	allowed, _, _ = limiter.Allow("b", policy, now)
+	assert.True(t, allowed)
+
+	allowed, retryAfter, _ = limiter.Allow("a", policy, now.Add(500*time.Millisecond))
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	allowed, _, _ = limiter.Allow("a", policy, now.Add(time.Second))
+	assert.True(t, allowed)
+}
+
+func TestPolicy(t *testing.T) {
+	cfg := ratelimit.Config{
+		Default: ratelimit.Policy{Rate: 10, Burst: 20},
+		Methods: map[string]ratelimit.Policy{
+			"loginadmin": {Rate: 0.2, Burst: 5},
+		},
+	}
+
+	assert.Equal(t, 5, cfg.Policy("LoginAdmin").Burst)
+	assert.Equal(t, 20, cfg.Policy("GetAllUser").Burst)
+	assert.False(t, ratelimit.Policy{}.Enabled())
+}
+
+func TestRedisLimiter(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	db, err := redis.NewDatabase(redis.Config{Addrs: []string{server.Addr()}})
+	require.NoError(t, err)
+	defer db.Close()
+
+	limiter := ratelimit.NewRedisLimiter(db)
+	policy := ratelimit.Policy{Rate: 1, Burst: 2}
+	now := time.Unix(1700000000, 0)
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := limiter.Allow("a", policy, now)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, retryAfter, err := limiter.Allow("a", policy, now)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	allowed, _, _ = limiter.Allow("b", policy, now)
+	assert.True(t, allowed)
+
+	allowed, _, _ = limiter.Allow("a", policy, now.Add(time.Second))
+	assert.True(t, allowed)
+
+	// The bucket is dropped once it would be full again
+	assert.True(t, server.Exists(ratelimit.KEY_PREFIX+"a"))
+	server.FastForward(3 * time.Second)
+	assert.False(t, server.Exists(ratelimit.KEY_PREFIX+"a"))
+}
+
+func TestNewLimiter(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(ratelimit.Config{}, nil, nil)
+	assert.NoError(t, err)
+	assert.IsType(t, &ratelimit.MemoryLimiter{}, limiter)
+
+	_, err = ratelimit.NewLimiter(ratelimit.Config{Backend: ratelimit.BACKEND_MEMCACHED}, nil, nil)
+	assert.Error(t, err)
+
+	_, err = ratelimit.NewLimiter(ratelimit.Config{Backend: ratelimit.BACKEND_REDIS}, nil, nil)
+	assert.Error(t, err)
+
+	db, err := redis.NewDatabase(redis.Config{Addrs: []string{"localhost:6379"}})
+	require.NoError(t, err)
+	defer db.Close()
+
+	limiter, err = ratelimit.NewLimiter(ratelimit.Config{Backend: ratelimit.BACKEND_REDIS}, nil, db)
+	assert.NoError(t, err)
+	assert.IsType(t, &ratelimit.RedisLimiter{}, limiter)
+}
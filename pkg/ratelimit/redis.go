@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisLimiter keeps buckets in redis, so the limit is shared by every
+// instance. Buckets are updated in transactions watching the bucket key.
+type RedisLimiter struct {
+	db *redis.Database
+}
+
+func NewRedisLimiter(db *redis.Database) *RedisLimiter {
+	return &RedisLimiter{
+		db: db,
+	}
+}
+
+func (l RedisLimiter) Allow(key string, policy Policy, now time.Time) (bool, time.Duration, error) {
+	ctx := context.Background()
+	key = KEY_PREFIX + key
+
+	for i := 0; i < casAttempts; i++ {
+		var (
+			allowed    bool
+			retryAfter time.Duration
+		)
+
+		err := l.db.Rdb.Watch(ctx, func(tx *goredis.Tx) error {
+			var b bucket
+
+			value, err := tx.Get(ctx, key).Bytes()
+			if err != nil && !errors.Is(err, goredis.Nil) {
+				return err
+			}
+
+			if err == nil {
+				err = json.Unmarshal(value, &b)
+				if err != nil {
+					return err
+				}
+			}
+
+			var next bucket
+			next, allowed, retryAfter = b.take(policy, now)
+
+			value, err = json.Marshal(next)
+			if err != nil {
+				return err
+			}
+
+			// The state expires once the bucket is full again
+			ttl := next.idle(policy) + time.Second
+
+			_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+				pipe.Set(ctx, key, value, ttl)
+				return nil
+			})
+
+			return err
+		}, key)
+
+		if errors.Is(err, goredis.TxFailedErr) {
+			continue
+		}
+
+		if err != nil {
+			return false, 0, err
+		}
+
+		return allowed, retryAfter, nil
+	}
+
+	return false, 0, fmt.Errorf("rate limit bucket %s is contended", key)
+}
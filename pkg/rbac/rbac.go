@@ -0,0 +1,121 @@
+// Package rbac resolves the permissions granted to roles from a cached copy of
+// the roles, so authorization does not hit the database on every request
+package rbac
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const DEFAULT_CACHE_TTL time.Duration = 30 * time.Second
+
+type (
+	Config struct {
+		// CacheTTLSecond is how long resolved roles are trusted before they are read
+		// again, so changes made on another instance take at most that long to apply
+		CacheTTLSecond int `mapstructure:"CACHE_TTL_SECOND"`
+	}
+
+	// Role is a role as far as authorization is concerned. Code is the role
+	// users and their tokens carry.
+	Role struct {
+		Code        int8
+		Name        string
+		Permissions []string
+	}
+
+	// Loader reads every role
+	Loader func(ctx context.Context) ([]Role, error)
+
+	// Resolver answers from the roles of the last load, loading them again once
+	// they are older than the ttl or were invalidated. Changes made on another
+	// instance show up within the ttl.
+	Resolver struct {
+		load     Loader
+		ttl      time.Duration
+		now      func() time.Time
+		mu       sync.Mutex
+		roles    map[int8]Role
+		loadedAt time.Time
+	}
+)
+
+func (cfg Config) CacheTTL() time.Duration {
+	if cfg.CacheTTLSecond <= 0 {
+		return DEFAULT_CACHE_TTL
+	}
+
+	return time.Duration(cfg.CacheTTLSecond) * time.Second
+}
+
+// NewResolver builds a resolver over load. now is the clock the ttl is checked
+// against, nil uses the wall clock.
+func NewResolver(load Loader, ttl time.Duration, now func() time.Time) *Resolver {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Resolver{
+		load: load,
+		ttl:  ttl,
+		now:  now,
+	}
+}
+
+func (r *Resolver) cached(ctx context.Context) (map[int8]Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.roles != nil && r.now().Sub(r.loadedAt) < r.ttl {
+		return r.roles, nil
+	}
+
+	roles, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.roles = make(map[int8]Role, len(roles))
+	for _, role := range roles {
+		r.roles[role.Code] = role
+	}
+	r.loadedAt = r.now()
+
+	return r.roles, nil
+}
+
+// HasPermission reports whether the role with the given code grants permission.
+// Unknown roles grant nothing.
+func (r *Resolver) HasPermission(ctx context.Context, code int8, permission string) (bool, error) {
+	roles, err := r.cached(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, granted := range roles[code].Permissions {
+		if granted == permission {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RoleName returns the name of the role with the given code, empty for unknown roles
+func (r *Resolver) RoleName(ctx context.Context, code int8) (string, error) {
+	roles, err := r.cached(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return roles[code].Name, nil
+}
+
+// Invalidate makes the next lookup load the roles again, after they changed
+func (r *Resolver) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roles = nil
+}
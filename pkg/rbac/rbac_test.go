@@ -0,0 +1,68 @@
+package rbac_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/rbac"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	loads := 0
+	roles := []rbac.Role{
+		{Code: 1, Name: "admin", Permissions: []string{"users.read", "users.write"}},
+		{Code: 2, Name: "customer"},
+	}
+
+	r := rbac.NewResolver(func(ctx context.Context) ([]rbac.Role, error) {
+		loads++
+		return roles, nil
+	}, time.Minute, func() time.Time { return now })
+
+	granted, err := r.HasPermission(ctx, 1, "users.write")
+	require.NoError(t, err)
+	assert.True(t, granted)
+
+	granted, _ = r.HasPermission(ctx, 2, "users.write")
+	assert.False(t, granted)
+
+	// Unknown roles grant nothing
+	granted, _ = r.HasPermission(ctx, 9, "users.read")
+	assert.False(t, granted)
+
+	name, _ := r.RoleName(ctx, 2)
+	assert.Equal(t, "customer", name)
+	assert.Equal(t, 1, loads)
+
+	// Changes show up once the cache is stale or invalidated
+	roles[1].Permissions = []string{"users.write"}
+
+	granted, _ = r.HasPermission(ctx, 2, "users.write")
+	assert.False(t, granted)
+
+	now = now.Add(time.Minute)
+	granted, _ = r.HasPermission(ctx, 2, "users.write")
+	assert.True(t, granted)
+	assert.Equal(t, 2, loads)
+
+	roles[1].Permissions = nil
+	r.Invalidate()
+	granted, _ = r.HasPermission(ctx, 2, "users.write")
+	assert.False(t, granted)
+	assert.Equal(t, 3, loads)
+}
+
+func TestResolverLoadError(t *testing.T) {
+	r := rbac.NewResolver(func(ctx context.Context) ([]rbac.Role, error) {
+		return nil, errors.New("down")
+	}, time.Minute, nil)
+
+	_, err := r.HasPermission(context.Background(), 1, "users.read")
+	assert.Error(t, err)
+}
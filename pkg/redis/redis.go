@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	MODE_STANDALONE string = "standalone"
+	MODE_SENTINEL   string = "sentinel"
+)
+
+// Config describes a standalone server, or the sentinels watching the master
+// named MasterName. Addrs holds the server address or the sentinel addresses.
+type Config struct {
+	Mode             string   `mapstructure:"MODE"`
+	Addrs            []string `mapstructure:"ADDRS"`
+	MasterName       string   `mapstructure:"MASTER_NAME"`
+	Username         string   `mapstructure:"USERNAME"`
+	Password         string   `mapstructure:"PASSWORD"`
+	SentinelPassword string   `mapstructure:"SENTINEL_PASSWORD"`
+	DB               int      `mapstructure:"DB"`
+}
+
+type Database struct {
+	Rdb goredis.UniversalClient
+}
+
+// incrementScript increments a counter and sets the expiry of counters it
+// creates, in one step so a counter never outlives its window
+var incrementScript = goredis.NewScript(`
+local value = redis.call('INCR', KEYS[1])
+if value == 1 and tonumber(ARGV[1]) > 0 then
+	redis.call('EXPIREAT', KEYS[1], ARGV[1])
+end
+return value
+`)
+
+// setMaxScript stores a counter unless the one already there is at least as
+// high, in one step so concurrent writers never lower it
+var setMaxScript = goredis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]))
+if current ~= nil and current >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[1])
+if tonumber(ARGV[2]) > 0 then
+	redis.call('EXPIREAT', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+func NewDatabase(cfg Config) (*Database, error) {
+	if len(cfg.Addrs) == 0 {
+		return nil, fmt.Errorf("redis address is required")
+	}
+
+	var rdb goredis.UniversalClient
+
+	switch cfg.Mode {
+	case "", MODE_STANDALONE:
+		rdb = goredis.NewClient(&goredis.Options{
+			Addr:     cfg.Addrs[0],
+			Username: cfg.Username,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		})
+	case MODE_SENTINEL:
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("redis master name is required in sentinel mode")
+		}
+
+		rdb = goredis.NewFailoverClient(&goredis.FailoverOptions{
+			MasterName:       cfg.MasterName,
+			SentinelAddrs:    cfg.Addrs,
+			SentinelPassword: cfg.SentinelPassword,
+			Username:         cfg.Username,
+			Password:         cfg.Password,
+			DB:               cfg.DB,
+		})
+	default:
+		return nil, fmt.Errorf("unknown redis mode: %s", cfg.Mode)
+	}
+
+	return &Database{
+		Rdb: rdb,
+	}, nil
+}
+
+// Set stores value under key until the unix time exp, 0 keeps it until deleted.
+// A value that would have expired already is removed instead.
+func (db Database) Set(ctx context.Context, key string, value interface{}, exp int64) error {
+	if exp == 0 {
+		return db.Rdb.Set(ctx, key, value, 0).Err()
+	}
+
+	ttl := time.Until(time.Unix(exp, 0))
+	if ttl <= 0 {
+		return db.Rdb.Del(ctx, key).Err()
+	}
+
+	return db.Rdb.Set(ctx, key, value, ttl).Err()
+}
+
+// Increment atomically adds one to the counter at key and returns the new value.
+// A missing counter is created to expire at the unix time exp, 0 never expires.
+func (db Database) Increment(ctx context.Context, key string, exp int64) (int64, error) {
+	return incrementScript.Run(ctx, db.Rdb, []string{key}, exp).Int64()
+}
+
+// SetMax stores the counter value under key until the unix time exp, unless the
+// counter already there is at least as high. 0 never expires.
+func (db Database) SetMax(ctx context.Context, key string, value int64, exp int64) error {
+	return setMaxScript.Run(ctx, db.Rdb, []string{key}, value, exp).Err()
+}
+
+func (db Database) Close() error {
+	return db.Rdb.Close()
+}
@@ -0,0 +1,23 @@
+package redis_test
+
+import (
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDatabase(t *testing.T) {
+	_, err := redis.NewDatabase(redis.Config{})
+	assert.Error(t, err)
+
+	_, err = redis.NewDatabase(redis.Config{Mode: redis.MODE_SENTINEL, Addrs: []string{"localhost:26379"}})
+	assert.Error(t, err)
+
+	_, err = redis.NewDatabase(redis.Config{Mode: "cluster", Addrs: []string{"localhost:6379"}})
+	assert.Error(t, err)
+
+	db, err := redis.NewDatabase(redis.Config{Mode: redis.MODE_SENTINEL, Addrs: []string{"localhost:26379"}, MasterName: "mymaster"})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+}
@@ -0,0 +1,45 @@
+package totp
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+const (
+	RecoveryCodeCount = 10
+
+	// Lowercase RFC 4648 base32, 32 divides 256 so every letter is equally likely
+	recoveryAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+	recoveryLength   = 10
+)
+
+// GenerateRecoveryCodes returns n random one time codes formatted as xxxxx-xxxxx
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		b := make([]byte, recoveryLength)
+
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range b {
+			b[j] = recoveryAlphabet[int(b[j])%len(recoveryAlphabet)]
+		}
+
+		codes = append(codes, string(b[:recoveryLength/2])+"-"+string(b[recoveryLength/2:]))
+	}
+
+	return codes, nil
+}
+
+// NormalizeRecoveryCode strips the formatting a user may or may not type, so the
+// code hashes the same way it did when it was issued
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+
+	return strings.Join(strings.Fields(code), "")
+}
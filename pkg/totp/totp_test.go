@@ -0,0 +1,55 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 appendix B, SHA1 vectors truncated to 6 digits
+func TestGenerateCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, want := range vectors {
+		code, err := totp.GenerateCode(secret, time.Unix(unix, 0))
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		code, _ := totp.GenerateCode(secret, now.Add(-30*time.Second))
+
+		step, ok := totp.Validate(secret, code, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, now.Add(-30*time.Second).Unix()/30, step)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		code, _ := totp.GenerateCode(secret, now.Add(-2*time.Minute))
+
+		_, ok := totp.Validate(secret, code, now)
+
+		assert.False(t, ok)
+	})
+}
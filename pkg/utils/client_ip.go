@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type clientIPKey struct{}
+
+// TrustedProxies are the peers allowed to tell the address of the client they
+// call on behalf of. Loopback is always trusted, it is the gateway serving in
+// the same process.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies reads proxies given as addresses or CIDR ranges
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	trusted := make(TrustedProxies, 0, len(proxies))
+
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+			}
+
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+
+			trusted = append(trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+
+		trusted = append(trusted, ipNet)
+	}
+
+	return trusted, nil
+}
+
+// Trusts reports whether the peer at ip may forward the address of a client
+func (tp TrustedProxies) Trusts(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	if parsed.IsLoopback() {
+		return true
+	}
+
+	for _, ipNet := range tp {
+		if ipNet.Contains(parsed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+// ResolveClientIP returns the address of the caller. A trusted proxy appends the
+// address it was called from as the last X-Forwarded-For entry, earlier entries
+// are client supplied and not trusted. Anyone else calls from their own address,
+// whatever header they send.
+func ResolveClientIP(ctx context.Context, trusted TrustedProxies) string {
+	ip := peerIP(ctx)
+	if !trusted.Trusts(ip) {
+		return ip
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		values := md.Get("x-forwarded-for")
+		if len(values) > 0 {
+			hops := strings.Split(values[len(values)-1], ",")
+			forwarded := strings.TrimSpace(hops[len(hops)-1])
+			if forwarded != "" {
+				return forwarded
+			}
+		}
+	}
+
+	return ip
+}
+
+// WithClientIP stores the resolved address of the caller for ClientIP
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the address of the caller as resolved for the request, or
+// the peer address when it was not resolved
+func ClientIP(ctx context.Context) string {
+	ip, ok := ctx.Value(clientIPKey{}).(string)
+	if ok {
+		return ip
+	}
+
+	return peerIP(ctx)
+}
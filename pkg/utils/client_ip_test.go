@@ -0,0 +1,59 @@
+package utils_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/digisata/auth-service/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func callFrom(addr string, forwardedFor ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 40000}})
+	if len(forwardedFor) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor[0]))
+	}
+
+	return ctx
+}
+
+func TestResolveClientIP(t *testing.T) {
+	trusted, err := utils.ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.10"})
+	require.NoError(t, err)
+
+	// The gateway calls over loopback and forwards the address it was called from
+	assert.Equal(t, "203.0.113.7", utils.ResolveClientIP(callFrom("127.0.0.1", "198.51.100.1, 203.0.113.7"), trusted))
+
+	// Configured proxies are believed too
+	assert.Equal(t, "203.0.113.7", utils.ResolveClientIP(callFrom("10.1.2.3", "203.0.113.7"), trusted))
+	assert.Equal(t, "203.0.113.7", utils.ResolveClientIP(callFrom("192.168.1.10", "203.0.113.7"), trusted))
+
+	// Anyone else calling the gRPC port directly can not pick an address
+	assert.Equal(t, "198.51.100.9", utils.ResolveClientIP(callFrom("198.51.100.9", "203.0.113.7"), trusted))
+	assert.Equal(t, "192.168.1.11", utils.ResolveClientIP(callFrom("192.168.1.11", "203.0.113.7"), trusted))
+
+	// A trusted proxy that forwards nothing is the caller itself
+	assert.Equal(t, "10.1.2.3", utils.ResolveClientIP(callFrom("10.1.2.3"), trusted))
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := utils.ParseTrustedProxies([]string{"not-an-ip"})
+	assert.Error(t, err)
+
+	_, err = utils.ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	ctx := callFrom("198.51.100.9", "203.0.113.7")
+
+	// Unresolved requests never take the header's word
+	assert.Equal(t, "198.51.100.9", utils.ClientIP(ctx))
+
+	ctx = utils.WithClientIP(ctx, "203.0.113.7")
+	assert.Equal(t, "203.0.113.7", utils.ClientIP(ctx))
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// UserAgent returns the user agent of the caller. The gateway forwards the one
+// of the HTTP client as grpcgateway-user-agent, direct gRPC calls carry their own.
+func UserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		values := md.Get(key)
+		if len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	return ""
+}
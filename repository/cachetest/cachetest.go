@@ -0,0 +1,77 @@
+// Package cachetest holds the tests every cache repository has to pass
+package cachetest
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CacheRepository interface {
+	Set(req domain.CacheItem) error
+	Get(key string) (domain.CacheItem, error)
+	Delete(key string) error
+	Increment(key string, exp int32) (int64, error)
+}
+
+// Run tests cr, advance moves the clock of the cache forward. Keys are unique to
+// the run so a shared server can be used.
+func Run(t *testing.T, cr CacheRepository, advance func(d time.Duration)) {
+	prefix := fmt.Sprintf("cachetest:%d:", time.Now().UnixNano())
+
+	t.Run("SetGetDelete", func(t *testing.T) {
+		key := prefix + "item"
+
+		_, err := cr.Get(key)
+		assert.ErrorIs(t, err, domain.ErrCacheMiss)
+
+		require.NoError(t, cr.Set(domain.CacheItem{Key: key, Value: "value"}))
+
+		item, err := cr.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, key, item.Key)
+		assert.Equal(t, "value", item.Value)
+
+		require.NoError(t, cr.Delete(key))
+		_, err = cr.Get(key)
+		assert.ErrorIs(t, err, domain.ErrCacheMiss)
+		assert.ErrorIs(t, cr.Delete(key), domain.ErrCacheMiss)
+	})
+
+	t.Run("Increment", func(t *testing.T) {
+		key := prefix + "counter"
+
+		for want := int64(1); want <= 3; want++ {
+			got, err := cr.Increment(key, 0)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		item := prefix + "expiring"
+		counter := prefix + "expiring_counter"
+		exp := int32(time.Now().Add(time.Second).Unix())
+
+		require.NoError(t, cr.Set(domain.CacheItem{Key: item, Value: "value", Exp: exp}))
+
+		_, err := cr.Increment(counter, exp)
+		require.NoError(t, err)
+		got, err := cr.Increment(counter, exp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), got)
+
+		advance(2 * time.Second)
+
+		_, err = cr.Get(item)
+		assert.ErrorIs(t, err, domain.ErrCacheMiss)
+
+		got, err = cr.Increment(counter, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), got)
+	})
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+
 	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/digisata/auth-service/domain"
 	"github.com/digisata/auth-service/pkg/memcached"
@@ -35,6 +37,10 @@ func (r CacheRepository) Get(key string) (domain.CacheItem, error) {
 
 	it, err := r.memcachedDB.Get(key)
 	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return item, domain.ErrCacheMiss
+		}
+
 		return item, err
 	}
 
@@ -50,8 +56,41 @@ func (r CacheRepository) Get(key string) (domain.CacheItem, error) {
 func (r CacheRepository) Delete(key string) error {
 	err := r.memcachedDB.Delete(key)
 	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return domain.ErrCacheMiss
+		}
+
 		return err
 	}
 
 	return nil
 }
+
+// Increment atomically adds one to the counter at key and returns the new value.
+// A missing counter is created with exp, which later increments don't extend.
+func (r CacheRepository) Increment(key string, exp int32) (int64, error) {
+	for {
+		val, err := r.memcachedDB.Increment(key, 1)
+		if err == nil {
+			return int64(val), nil
+		}
+
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, err
+		}
+
+		err = r.memcachedDB.Add(&memcache.Item{
+			Key:        key,
+			Value:      []byte("1"),
+			Expiration: exp,
+		})
+		if err == nil {
+			return 1, nil
+		}
+
+		// Someone else created the counter in between, increment theirs
+		if !errors.Is(err, memcache.ErrNotStored) {
+			return 0, err
+		}
+	}
+}
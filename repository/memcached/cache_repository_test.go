@@ -0,0 +1,28 @@
+package repository_test
+
+import (
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/memcached"
+	"github.com/digisata/auth-service/repository/cachetest"
+	repository "github.com/digisata/auth-service/repository/memcached"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCacheRepository needs a memcached server at MEMCACHED_ADDR
+func TestCacheRepository(t *testing.T) {
+	addr := os.Getenv("MEMCACHED_ADDR")
+	if addr == "" {
+		t.Skip("MEMCACHED_ADDR is not set")
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	db := memcached.NewDatabase(memcached.Config{DBHost: host, DBPort: port})
+
+	cachetest.Run(t, repository.NewCacheRepository(db), time.Sleep)
+}
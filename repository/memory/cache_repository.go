@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/cache"
+)
+
+type CacheRepository struct {
+	memory *cache.Memory
+}
+
+func NewCacheRepository(memory *cache.Memory) *CacheRepository {
+	return &CacheRepository{
+		memory: memory,
+	}
+}
+
+func (r CacheRepository) Set(req domain.CacheItem) error {
+	r.memory.Set(req.Key, []byte(req.Value), int64(req.Exp))
+
+	return nil
+}
+
+func (r CacheRepository) Get(key string) (domain.CacheItem, error) {
+	var item domain.CacheItem
+
+	value, ok := r.memory.Get(key)
+	if !ok {
+		return item, domain.ErrCacheMiss
+	}
+
+	item = domain.CacheItem{
+		Key:   key,
+		Value: string(value),
+	}
+
+	return item, nil
+}
+
+func (r CacheRepository) Delete(key string) error {
+	if !r.memory.Delete(key) {
+		return domain.ErrCacheMiss
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to the counter at key and returns the new value.
+// A missing counter is created with exp, which later increments don't extend.
+func (r CacheRepository) Increment(key string, exp int32) (int64, error) {
+	return r.memory.Increment(key, int64(exp))
+}
@@ -0,0 +1,20 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/pkg/cache"
+	"github.com/digisata/auth-service/repository/cachetest"
+	repository "github.com/digisata/auth-service/repository/memory"
+)
+
+func TestCacheRepository(t *testing.T) {
+	now := time.Now()
+	memory := cache.NewMemory(cache.Config{}, func() time.Time { return now })
+	defer memory.Close()
+
+	cachetest.Run(t, repository.NewCacheRepository(memory), func(d time.Duration) {
+		now = now.Add(d)
+	})
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ClientRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewClientRepository(db mongo.Database, collection string) *ClientRepository {
+	return &ClientRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r ClientRepository) Create(ctx context.Context, req domain.Client) error {
+	collection := r.db.Collection(r.collection)
+	client := req
+
+	now := time.Now().Local().Unix()
+	client.CreatedAt = now
+	client.UpdatedAt = now
+	_, err := collection.InsertOne(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r ClientRepository) GetAll(ctx context.Context) ([]domain.Client, error) {
+	collection := r.db.Collection(r.collection)
+	opts := options.Find().SetProjection(bson.D{{Key: "secret_hash", Value: 0}})
+
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var clients []domain.Client
+
+	err = cursor.All(ctx, &clients)
+	if err != nil {
+		return nil, err
+	}
+
+	if clients == nil {
+		return []domain.Client{}, nil
+	}
+
+	return clients, nil
+}
+
+func (r ClientRepository) GetByClientID(ctx context.Context, clientID string) (domain.Client, error) {
+	collection := r.db.Collection(r.collection)
+
+	var client domain.Client
+
+	err := collection.FindOne(ctx, bson.M{"client_id": clientID}).Decode(&client)
+	if err != nil {
+		return client, err
+	}
+
+	return client, nil
+}
+
+func (r ClientRepository) Update(ctx context.Context, req domain.UpdateClient) error {
+	collection := r.db.Collection(r.collection)
+
+	updateClient := req
+	updateClient.UpdatedAt = time.Now().Local().Unix()
+
+	_, err := collection.UpdateOne(ctx, bson.M{"client_id": req.ClientID}, bson.M{"$set": updateClient})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r ClientRepository) UpdateSecret(ctx context.Context, clientID, secretHash string) error {
+	collection := r.db.Collection(r.collection)
+
+	update := bson.M{
+		"secret_hash": secretHash,
+		"updated_at":  time.Now().Local().Unix(),
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"client_id": clientID}, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r ClientRepository) Delete(ctx context.Context, clientID string) error {
+	collection := r.db.Collection(r.collection)
+
+	_, err := collection.DeleteOne(ctx, bson.M{"client_id": clientID})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PasswordHistoryRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewPasswordHistoryRepository(db mongo.Database, collection string) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r PasswordHistoryRepository) GetByUserID(ctx context.Context, userID string) (domain.PasswordHistory, error) {
+	collection := r.db.Collection(r.collection)
+
+	var history domain.PasswordHistory
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return history, err
+	}
+
+	err = collection.FindOne(ctx, bson.M{"user_id": idHex}).Decode(&history)
+	if err != nil {
+		return history, err
+	}
+
+	return history, nil
+}
+
+// Push appends a password hash and drops all but the newest keep hashes
+func (r PasswordHistoryRepository) Push(ctx context.Context, userID, password string, keep int) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$push": bson.M{
+			"passwords": bson.M{
+				"$each":  []string{password},
+				"$slice": -keep,
+			},
+		},
+		"$set": bson.M{
+			"updated_at": time.Now().Local().Unix(),
+		},
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"user_id": idHex}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PermissionRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewPermissionRepository(db mongo.Database, collection string) *PermissionRepository {
+	return &PermissionRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// EnsureIndexes makes permission names unique
+func (r PermissionRepository) EnsureIndexes(ctx context.Context) error {
+	collection := r.db.Collection(r.collection)
+
+	_, err := collection.CreateIndexes(ctx, []mongodriver.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PermissionRepository) GetAll(ctx context.Context) ([]domain.Permission, error) {
+	collection := r.db.Collection(r.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []domain.Permission
+
+	err = cursor.All(ctx, &permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if permissions == nil {
+		return []domain.Permission{}, nil
+	}
+
+	return permissions, nil
+}
+
+func (r PermissionRepository) GetByName(ctx context.Context, name string) (domain.Permission, error) {
+	collection := r.db.Collection(r.collection)
+
+	var permission domain.Permission
+
+	err := collection.FindOne(ctx, bson.M{"name": name}).Decode(&permission)
+	if err != nil {
+		return permission, err
+	}
+
+	return permission, nil
+}
+
+// Upsert creates a permission unless one with the same name exists, in which case
+// only its description is refreshed
+func (r PermissionRepository) Upsert(ctx context.Context, req domain.Permission) error {
+	collection := r.db.Collection(r.collection)
+
+	update := bson.M{
+		"$set": bson.M{"description": req.Description},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": time.Now().Local().Unix(),
+		},
+	}
+	opts := options.Update().SetUpsert(true)
+
+	_, err := collection.UpdateOne(ctx, bson.M{"name": req.Name}, update, opts)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
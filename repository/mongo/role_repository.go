@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RoleRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewRoleRepository(db mongo.Database, collection string) *RoleRepository {
+	return &RoleRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+// EnsureIndexes makes codes and names unique, two roles created at once can
+// not end up sharing either
+func (r RoleRepository) EnsureIndexes(ctx context.Context) error {
+	collection := r.db.Collection(r.collection)
+
+	_, err := collection.CreateIndexes(ctx, []mongodriver.IndexModel{
+		{Keys: bson.D{{Key: "code", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r RoleRepository) Create(ctx context.Context, req domain.Role) error {
+	collection := r.db.Collection(r.collection)
+	role := req
+
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+
+	now := time.Now().Local().Unix()
+	role.CreatedAt = now
+	role.UpdatedAt = now
+	_, err := collection.InsertOne(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r RoleRepository) GetAll(ctx context.Context) ([]domain.Role, error) {
+	collection := r.db.Collection(r.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "code", Value: 1}})
+
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []domain.Role
+
+	err = cursor.All(ctx, &roles)
+	if err != nil {
+		return nil, err
+	}
+
+	if roles == nil {
+		return []domain.Role{}, nil
+	}
+
+	return roles, nil
+}
+
+func (r RoleRepository) GetByID(ctx context.Context, id string) (domain.Role, error) {
+	collection := r.db.Collection(r.collection)
+
+	var role domain.Role
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return role, err
+	}
+
+	err = collection.FindOne(ctx, bson.M{"_id": idHex}).Decode(&role)
+	if err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+func (r RoleRepository) GetByName(ctx context.Context, name string) (domain.Role, error) {
+	collection := r.db.Collection(r.collection)
+
+	var role domain.Role
+
+	err := collection.FindOne(ctx, bson.M{"name": name}).Decode(&role)
+	if err != nil {
+		return role, err
+	}
+
+	return role, nil
+}
+
+func (r RoleRepository) Update(ctx context.Context, req domain.UpdateRole) error {
+	collection := r.db.Collection(r.collection)
+
+	updateRole := req
+	updateRole.UpdatedAt = time.Now().Local().Unix()
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": req.ID}, bson.M{"$set": updateRole})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r RoleRepository) AddPermission(ctx context.Context, id primitive.ObjectID, permission string) error {
+	collection := r.db.Collection(r.collection)
+
+	update := bson.M{
+		"$addToSet": bson.M{"permissions": permission},
+		"$set":      bson.M{"updated_at": time.Now().Local().Unix()},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r RoleRepository) RemovePermission(ctx context.Context, id primitive.ObjectID, permission string) error {
+	collection := r.db.Collection(r.collection)
+
+	update := bson.M{
+		"$pull": bson.M{"permissions": permission},
+		"$set":  bson.M{"updated_at": time.Now().Local().Unix()},
+	}
+
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SecurityEventRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewSecurityEventRepository(db mongo.Database, collection string) *SecurityEventRepository {
+	return &SecurityEventRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r SecurityEventRepository) Create(ctx context.Context, req domain.SecurityEvent) error {
+	collection := r.db.Collection(r.collection)
+	event := req
+
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+
+	event.CreatedAt = time.Now().Local().Unix()
+	_, err := collection.InsertOne(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
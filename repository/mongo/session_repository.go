@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/mongo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SessionRepository struct {
+	db         mongo.Database
+	collection string
+}
+
+func NewSessionRepository(db mongo.Database, collection string) *SessionRepository {
+	return &SessionRepository{
+		db:         db,
+		collection: collection,
+	}
+}
+
+func (r SessionRepository) Create(ctx context.Context, req domain.Session) error {
+	collection := r.db.Collection(r.collection)
+	session := req
+
+	now := time.Now().Local().Unix()
+	session.CreatedAt = now
+	session.LastUsedAt = now
+	_, err := collection.InsertOne(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r SessionRepository) GetByID(ctx context.Context, id string) (domain.Session, error) {
+	collection := r.db.Collection(r.collection)
+
+	var session domain.Session
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return session, err
+	}
+
+	err = collection.FindOne(ctx, bson.M{"_id": idHex}).Decode(&session)
+	if err != nil {
+		return session, err
+	}
+
+	return session, nil
+}
+
+// GetActiveByUserID returns the sessions of a user that are neither revoked nor
+// expired, most recently used first
+func (r SessionRepository) GetActiveByUserID(ctx context.Context, userID string) ([]domain.Session, error) {
+	collection := r.db.Collection(r.collection)
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": 0,
+		"expires_at": bson.M{"$gt": time.Now().Local().Unix()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "last_used_at", Value: -1}})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []domain.Session
+
+	err = cursor.All(ctx, &sessions)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessions == nil {
+		return []domain.Session{}, nil
+	}
+
+	return sessions, nil
+}
+
+func (r SessionRepository) Touch(ctx context.Context, id string, expiresAt int64) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"last_used_at": time.Now().Local().Unix(),
+		"expires_at":   expiresAt,
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r SessionRepository) Revoke(ctx context.Context, id string) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": idHex, "revoked_at": 0}
+	update := bson.M{"revoked_at": time.Now().Local().Unix()}
+
+	_, err = collection.UpdateOne(ctx, filter, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeByUserID marks every session of a user revoked, for when all tokens of
+// the user were revoked at once
+func (r SessionRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	collection := r.db.Collection(r.collection)
+
+	filter := bson.M{"user_id": userID, "revoked_at": 0}
+	update := bson.M{"revoked_at": time.Now().Local().Unix()}
+
+	_, err := collection.UpdateMany(ctx, filter, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -40,7 +40,7 @@ func (r UserRepository) Create(ctx context.Context, req domain.User) error {
 
 func (r UserRepository) GetAll(ctx context.Context, req domain.GetAllUserRequest) ([]domain.User, error) {
 	collection := r.db.Collection(r.collection)
-	opts := options.Find().SetProjection(bson.D{{Key: "password", Value: 0}})
+	opts := options.Find().SetProjection(bson.D{{Key: "password", Value: 0}, {Key: "totp_secret", Value: 0}, {Key: "recovery_codes", Value: 0}})
 
 	filter := bson.M{}
 	if req.Search != "" {
@@ -131,3 +131,144 @@ func (r UserRepository) Delete(ctx context.Context, req domain.DeleteUser) error
 
 	return nil
 }
+
+func (r UserRepository) UpdateMFA(ctx context.Context, id string, req domain.UpdateMFA) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	updateMFA := req
+	updateMFA.UpdatedAt = time.Now().Local().Unix()
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, bson.M{"$set": updateMFA})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode removes a recovery code hash in a single update, so two
+// concurrent logins can never both spend the same code
+func (r UserRepository) ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"_id": idHex, "recovery_codes": codeHash}
+	update := bson.M{
+		"$pull": bson.M{"recovery_codes": codeHash},
+		"$set":  bson.M{"updated_at": time.Now().Local().Unix()},
+	}
+
+	res, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (r UserRepository) SetRecoveryCodes(ctx context.Context, id string, codeHashes []string) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"recovery_codes": codeHashes,
+		"updated_at":     time.Now().Local().Unix(),
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r UserRepository) SetEmailVerified(ctx context.Context, id string) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"email_verified": true,
+		"updated_at":     time.Now().Local().Unix(),
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r UserRepository) UpdatePassword(ctx context.Context, id, password string) error {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"password":   password,
+		"updated_at": time.Now().Local().Unix(),
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, bson.M{"$set": update})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTokenEpoch returns the token generation of a user, 0 for users that never
+// had their tokens revoked
+func (r UserRepository) GetTokenEpoch(ctx context.Context, id string) (int64, error) {
+	user, err := r.GetByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return user.TokenEpoch, nil
+}
+
+// IncrementTokenEpoch moves a user on to the next token generation and returns
+// it. The increment is a single update, so concurrent revocations each count.
+func (r UserRepository) IncrementTokenEpoch(ctx context.Context, id string) (int64, error) {
+	collection := r.db.Collection(r.collection)
+
+	idHex, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return 0, err
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"token_epoch": 1},
+		"$set": bson.M{"updated_at": time.Now().Local().Unix()},
+	}
+
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": idHex}, update)
+	if err != nil {
+		return 0, err
+	}
+
+	// A user that does not exist was not updated and is not found either
+	return r.GetTokenEpoch(ctx, id)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type CacheRepository struct {
+	redisDB *redis.Database
+}
+
+func NewCacheRepository(redisDB *redis.Database) *CacheRepository {
+	return &CacheRepository{
+		redisDB: redisDB,
+	}
+}
+
+func (r CacheRepository) Set(req domain.CacheItem) error {
+	err := r.redisDB.Set(context.Background(), req.Key, req.Value, int64(req.Exp))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r CacheRepository) Get(key string) (domain.CacheItem, error) {
+	var item domain.CacheItem
+
+	value, err := r.redisDB.Rdb.Get(context.Background(), key).Result()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return item, domain.ErrCacheMiss
+		}
+
+		return item, err
+	}
+
+	item = domain.CacheItem{
+		Key:   key,
+		Value: value,
+	}
+
+	return item, nil
+}
+
+func (r CacheRepository) Delete(key string) error {
+	deleted, err := r.redisDB.Rdb.Del(context.Background(), key).Result()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrCacheMiss
+	}
+
+	return nil
+}
+
+// Increment atomically adds one to the counter at key and returns the new value.
+// A missing counter is created with exp, which later increments don't extend.
+func (r CacheRepository) Increment(key string, exp int32) (int64, error) {
+	return r.redisDB.Increment(context.Background(), key, int64(exp))
+}
@@ -0,0 +1,21 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/digisata/auth-service/pkg/redis"
+	"github.com/digisata/auth-service/repository/cachetest"
+	repository "github.com/digisata/auth-service/repository/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheRepository(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	db, err := redis.NewDatabase(redis.Config{Addrs: []string{server.Addr()}})
+	require.NoError(t, err)
+	defer db.Close()
+
+	cachetest.Run(t, repository.NewCacheRepository(db), server.FastForward)
+}
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/mailer"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/rbac"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AccountUsecase struct {
+	jwt     *jwtio.JSONWebToken
+	cfg     *bootstrap.Config
+	ur      UserRepository
+	sr      SessionRepository
+	cr      CacheRepository
+	mailer  mailer.Mailer
+	roles   *rbac.Resolver
+	policy  *password.Policy
+	hasher  *password.Hasher
+	history passwordHistory
+	logger  *zap.SugaredLogger
+	timeout time.Duration
+}
+
+var _ mailer.Mailer = (*mailer.SMTPMailer)(nil)
+var _ mailer.Mailer = (*mailer.DirMailer)(nil)
+
+func NewAccountUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur UserRepository, phr PasswordHistoryRepository, sr SessionRepository, cr CacheRepository, mailer mailer.Mailer, roles *rbac.Resolver, policy *password.Policy, hasher *password.Hasher, logger *zap.SugaredLogger, timeout time.Duration) *AccountUsecase {
+	return &AccountUsecase{
+		jwt:     jwt,
+		cfg:     cfg,
+		ur:      ur,
+		sr:      sr,
+		cr:      cr,
+		mailer:  mailer,
+		roles:   roles,
+		policy:  policy,
+		hasher:  hasher,
+		history: passwordHistory{cfg: cfg.Password, phr: phr, roles: roles, hasher: hasher},
+		logger:  logger,
+		timeout: timeout,
+	}
+}
+
+// link builds an emailed link to the frontend carrying token
+func (uc AccountUsecase) link(path, token string) string {
+	return uc.cfg.Mailer.LinkBaseURL + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// sendInBackground emails msg without keeping the caller waiting, so the time a
+// request takes does not tell whether an email went out. Delivery errors are
+// only logged.
+func (uc AccountUsecase) sendInBackground(msg mailer.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), uc.timeout)
+		defer cancel()
+
+		err := uc.mailer.Send(ctx, msg)
+		if err != nil {
+			uc.logger.Errorw(constants.ERROR,
+				"message", "failed to send email",
+				"subject", msg.Subject,
+				"error", err.Error(),
+			)
+		}
+	}()
+}
+
+// consumeActionToken makes an action token single use, whoever deletes its jti
+// from the cache first owns it
+func (uc AccountUsecase) consumeActionToken(prefix, jti string) error {
+	err := uc.cr.Delete(prefix + jti)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (uc AccountUsecase) SendVerificationEmail(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	user, err := uc.ur.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.NotFound, fmt.Sprintf("User with id %s not found", userID))
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if user.EmailVerified {
+		return status.Error(codes.FailedPrecondition, "Email is already verified")
+	}
+
+	now := time.Now()
+	expiry := time.Minute * time.Duration(uc.cfg.Mailer.VerificationExpiryMinute)
+
+	// The email is part of the token, a link sent to an old address stops working
+	token, jti, err := uc.jwt.CreateActionToken(domain.PURPOSE_VERIFY_EMAIL, userID, user.Email, now, expiry)
+	if err != nil {
+		return err
+	}
+
+	err = uc.cr.Set(domain.CacheItem{
+		Key: domain.EMAIL_VERIFICATION_KEY_PREFIX + jti,
+		Exp: int32(now.Add(expiry).Unix()),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email address. It expires in %d minutes.\n\n%s\n",
+			user.Name, uc.cfg.Mailer.VerificationExpiryMinute, uc.link("/verify-email", token)),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (uc AccountUsecase) VerifyEmail(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims, err := uc.jwt.VerifyActionToken(token, domain.PURPOSE_VERIFY_EMAIL)
+	if err != nil {
+		return err
+	}
+
+	err = uc.consumeActionToken(domain.EMAIL_VERIFICATION_KEY_PREFIX, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.ur.GetByID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if user.Email != claims.Email {
+		return status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	err = uc.ur.SetEmailVerified(ctx, claims.Subject)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// RequestPasswordReset emails a reset link when the address belongs to an active
+// user. It always succeeds, failures are only logged, so neither the response nor
+// its timing can be used to probe emails.
+func (uc AccountUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	err := uc.requestPasswordReset(ctx, email)
+	if err != nil {
+		uc.logger.Errorw(constants.ERROR,
+			"message", "failed to request password reset",
+			"error", err.Error(),
+		)
+	}
+
+	return nil
+}
+
+func (uc AccountUsecase) requestPasswordReset(ctx context.Context, email string) error {
+	user, err := uc.ur.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if !user.IsActive || user.DeletedAt != 0 {
+		return nil
+	}
+
+	token, err := oauth.GenerateToken()
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	now := time.Now()
+	expiry := time.Minute * time.Duration(uc.cfg.Mailer.PasswordResetExpiryMinute)
+
+	// Only the hash is kept, a leaked cache never holds a usable reset token
+	err = uc.cr.Set(domain.CacheItem{
+		Key:   domain.PASSWORD_RESET_KEY_PREFIX + oauth.HashToken(token),
+		Value: user.ID.Hex(),
+		Exp:   int32(now.Add(expiry).Unix()),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	uc.sendInBackground(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to choose a new password. It expires in %d minutes.\n\n%s\n\nIf you did not ask for this, you can ignore this email.\n",
+			user.Name, uc.cfg.Mailer.PasswordResetExpiryMinute, uc.link("/reset-password", token)),
+	})
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the user out
+// everywhere, whoever had the old password loses their sessions too. The token
+// is only used up once the new password passes the policy, so the user can try
+// again with the same link.
+func (uc AccountUsecase) ResetPassword(ctx context.Context, req domain.ResetPasswordRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	key := domain.PASSWORD_RESET_KEY_PREFIX + oauth.HashToken(req.Token)
+
+	item, err := uc.cr.Get(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	userID := item.Value
+
+	user, err := uc.ur.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if !user.IsActive || user.DeletedAt != 0 {
+		return status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	err = checkPassword(uc.policy, "new_password", req.NewPassword, user.Name, user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = uc.history.check(ctx, "new_password", userID, user.Role, user.Password, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	err = uc.cr.Delete(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	encryptedPassword, err := uc.hasher.Hash(req.NewPassword)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.history.record(ctx, userID, user.Role, user.Password)
+	if err != nil {
+		return err
+	}
+
+	err = uc.ur.UpdatePassword(ctx, userID, encryptedPassword)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = revokeUserTokens(ctx, uc.jwt, uc.sr, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (uc AccountUsecase) isMagicLinkEnabled(ctx context.Context, role int8) (bool, error) {
+	name, err := uc.roles.RoleName(ctx, role)
+	if err != nil {
+		return false, status.Error(codes.Internal, err.Error())
+	}
+
+	if name == "" {
+		return false, nil
+	}
+
+	for _, val := range uc.cfg.Mailer.MagicLinkRoles {
+		if strings.EqualFold(val, name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RequestMagicLink emails a single use sign in link when the address belongs to
+// an active user whose role opted in. Like RequestPasswordReset it always succeeds.
+func (uc AccountUsecase) RequestMagicLink(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	err := uc.requestMagicLink(ctx, email)
+	if err != nil {
+		uc.logger.Errorw(constants.ERROR,
+			"message", "failed to request magic link",
+			"error", err.Error(),
+		)
+	}
+
+	return nil
+}
+
+func (uc AccountUsecase) requestMagicLink(ctx context.Context, email string) error {
+	user, err := uc.ur.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if !user.IsActive || user.DeletedAt != 0 {
+		return nil
+	}
+
+	enabled, err := uc.isMagicLinkEnabled(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+
+	if !enabled {
+		return nil
+	}
+
+	now := time.Now()
+	expiry := time.Minute * time.Duration(uc.cfg.Mailer.MagicLinkExpiryMinute)
+
+	token, jti, err := uc.jwt.CreateActionToken(domain.PURPOSE_MAGIC_LINK, user.ID.Hex(), user.Email, now, expiry)
+	if err != nil {
+		return err
+	}
+
+	err = uc.cr.Set(domain.CacheItem{
+		Key: domain.MAGIC_LINK_KEY_PREFIX + jti,
+		Exp: int32(now.Add(expiry).Unix()),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	uc.sendInBackground(mailer.Message{
+		To:      user.Email,
+		Subject: "Your sign in link",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to sign in. It expires in %d minutes and works once.\n\n%s\n\nIf you did not ask for this, you can ignore this email.\n",
+			user.Name, uc.cfg.Mailer.MagicLinkExpiryMinute, uc.link("/magic-link", token)),
+	})
+
+	return nil
+}
+
+// ConsumeMagicLink signs the user in with a magic link token. It answers like the
+// password logins, with an MFA challenge instead of tokens when MFA is enabled,
+// and the tokens are for the customer portal.
+func (uc AccountUsecase) ConsumeMagicLink(ctx context.Context, token string) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims, err := uc.jwt.VerifyActionToken(token, domain.PURPOSE_MAGIC_LINK)
+	if err != nil {
+		return res, err
+	}
+
+	err = uc.consumeActionToken(domain.MAGIC_LINK_KEY_PREFIX, claims.ID)
+	if err != nil {
+		return res, err
+	}
+
+	user, err := uc.ur.GetByID(ctx, claims.Subject)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return res, status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	if user.Email != claims.Email {
+		return res, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	enabled, err := uc.isMagicLinkEnabled(ctx, user.Role)
+	if err != nil {
+		return res, err
+	}
+
+	if !enabled {
+		return res, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+
+	if !user.IsActive || user.DeletedAt != 0 {
+		return res, status.Error(codes.Unauthenticated, "Your account has been deleted")
+	}
+
+	// Opening the link proves the user owns the address
+	if !user.EmailVerified {
+		err = uc.ur.SetEmailVerified(ctx, claims.Subject)
+		if err != nil {
+			return res, status.Error(codes.Internal, err.Error())
+		}
+
+		user.EmailVerified = true
+	}
+
+	// Magic links sign in to the customer portal, the one LoginCustomer uses
+	audience := domain.PORTAL_CUSTOMER
+
+	if user.MFAEnabled {
+		return createMFAChallenge(uc.cfg, uc.cr, user, audience)
+	}
+
+	res, err = issueTokens(ctx, uc.jwt, uc.cfg, uc.sr, tokenRequest{user: user, audience: audience})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// CheckPasswordStrength reports every rule a password breaks, so the UI can show
+// feedback before the password is submitted
+func (uc AccountUsecase) CheckPasswordStrength(ctx context.Context, req domain.CheckPasswordStrengthRequest) domain.CheckPasswordStrengthResponse {
+	res := domain.CheckPasswordStrengthResponse{
+		Violations: []domain.PasswordViolation{},
+	}
+
+	for _, val := range uc.policy.Check(req.Password, req.Name, req.Email) {
+		res.Violations = append(res.Violations, domain.PasswordViolation{
+			Rule:        val.Rule,
+			Description: val.Description,
+		})
+	}
+
+	res.Valid = len(res.Violations) == 0
+
+	return res
+}
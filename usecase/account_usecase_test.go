@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// emailedToken reads the token off the link in an email
+func emailedToken(t *testing.T, body string) string {
+	start := strings.Index(body, "http")
+	require.GreaterOrEqual(t, start, 0)
+
+	link, err := url.Parse(strings.Fields(body[start:])[0])
+	require.NoError(t, err)
+
+	return link.Query().Get("token")
+}
+
+// Tokens issued in the same second as the reset, but after it, must keep working
+// while every token issued before it is refused, whatever the clock says
+func TestResetPasswordRevokesEarlierTokens(t *testing.T) {
+	env := newTestEnv(t)
+	env.cfg.Mailer.LinkBaseURL = "https://app.example.com"
+	env.cfg.Mailer.PasswordResetExpiryMinute = 15
+
+	mail := &fakeMailer{}
+	uc := env.accountUsecase(t, mail)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	before, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	require.NoError(t, uc.RequestPasswordReset(context.Background(), user.Email))
+	sent := mail.messages(t, 1)
+
+	err = uc.ResetPassword(context.Background(), domain.ResetPasswordRequest{
+		Token:       emailedToken(t, sent[0].Body),
+		NewPassword: "correct-horse-battery",
+	})
+	require.NoError(t, err)
+
+	after, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	_, err = env.jwt.VerifyAccessToken(before.AccessToken)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = env.jwt.VerifyRefreshToken(before.RefreshToken)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = env.jwt.VerifyAccessToken(after.AccessToken)
+	assert.NoError(t, err)
+
+	_, err = env.jwt.VerifyRefreshToken(after.RefreshToken)
+	assert.NoError(t, err)
+
+	// The reset token is used up
+	err = uc.ResetPassword(context.Background(), domain.ResetPasswordRequest{
+		Token:       emailedToken(t, sent[0].Body),
+		NewPassword: "another-horse-battery",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// Unknown and inactive emails, and emails that could not be delivered, all get
+// the same answer
+func TestRequestPasswordResetHidesAccounts(t *testing.T) {
+	env := newTestEnv(t)
+	env.cfg.Mailer.PasswordResetExpiryMinute = 15
+
+	mail := &fakeMailer{err: errors.New("connection refused")}
+	uc := env.accountUsecase(t, mail)
+
+	user := env.addUser(t, domain.CUSTOMER)
+	inactive := env.addUser(t, domain.CUSTOMER)
+	require.NoError(t, env.ur.Update(context.Background(), domain.UpdateUser{ID: inactive.ID}))
+
+	for _, email := range []string{user.Email, inactive.Email, primitive.NewObjectID().Hex() + "@example.com"} {
+		assert.NoError(t, uc.RequestPasswordReset(context.Background(), email), email)
+	}
+}
+
+func TestRequestMagicLinkResolvesStoredRoles(t *testing.T) {
+	env := newTestEnv(t)
+	env.cfg.Mailer.MagicLinkRoles = []string{"partner"}
+	env.cfg.Mailer.MagicLinkExpiryMinute = 15
+
+	role, err := env.roleUsecase().Create(context.Background(), domain.Role{Name: "partner"})
+	require.NoError(t, err)
+
+	partner := env.addUser(t, domain.UserRole(role.Code))
+	customer := env.addUser(t, domain.CUSTOMER)
+
+	mail := &fakeMailer{}
+	uc := env.accountUsecase(t, mail)
+
+	require.NoError(t, uc.RequestMagicLink(context.Background(), customer.Email))
+	require.NoError(t, uc.RequestMagicLink(context.Background(), primitive.NewObjectID().Hex()+"@example.com"))
+	require.NoError(t, uc.RequestMagicLink(context.Background(), partner.Email))
+
+	// Only the partner gets a link, the other requests send nothing
+	sent := mail.messages(t, 1)
+	require.Len(t, sent, 1)
+	assert.Equal(t, partner.Email, sent[0].To)
+
+	// A failed delivery is not reported either
+	mail.err = errors.New("connection refused")
+	assert.NoError(t, uc.RequestMagicLink(context.Background(), partner.Email))
+}
+
+func TestConsumeMagicLinkSignsInToCustomerPortal(t *testing.T) {
+	env := newTestEnv(t)
+	env.cfg.Mailer.LinkBaseURL = "https://app.example.com"
+	env.cfg.Mailer.MagicLinkRoles = []string{domain.ROLE_NAMES[domain.CUSTOMER]}
+	env.cfg.Mailer.MagicLinkExpiryMinute = 15
+
+	mail := &fakeMailer{}
+	uc := env.accountUsecase(t, mail)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	require.NoError(t, uc.RequestMagicLink(context.Background(), user.Email))
+	sent := mail.messages(t, 1)
+
+	res, err := uc.ConsumeMagicLink(context.Background(), emailedToken(t, sent[0].Body))
+	require.NoError(t, err)
+
+	claims, err := env.jwt.VerifyAccessToken(res.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ROLE_NAMES[domain.CUSTOMER], jwtio.Audience(claims))
+
+	// The link is used up
+	_, err = uc.ConsumeMagicLink(context.Background(), emailedToken(t, sent[0].Body))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
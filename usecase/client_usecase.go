@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/oauth"
+	mongoRepo "github.com/digisata/auth-service/repository/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ClientUsecase struct {
+	clr     ClientRepository
+	timeout time.Duration
+}
+
+var _ ClientRepository = (*mongoRepo.ClientRepository)(nil)
+
+func NewClientUsecase(clr ClientRepository, timeout time.Duration) *ClientUsecase {
+	return &ClientUsecase{
+		clr:     clr,
+		timeout: timeout,
+	}
+}
+
+// generateClientSecret returns a new client secret and its bcrypt hash. The secret
+// itself is only ever shown once, in the create or rotate response.
+func generateClientSecret() (string, string, error) {
+	secret, err := oauth.GenerateToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", err
+	}
+
+	return secret, string(hash), nil
+}
+
+func validateClient(grantTypes, redirectURIs []string, accessTokenExpiryHour, refreshTokenExpiryHour int) error {
+	for _, grantType := range grantTypes {
+		supported := false
+		for _, val := range domain.GRANT_TYPES {
+			if grantType == val {
+				supported = true
+				break
+			}
+		}
+
+		if !supported {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Unsupported grant type %s", grantType))
+		}
+	}
+
+	for _, redirectURI := range redirectURIs {
+		u, err := url.Parse(redirectURI)
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Fragment != "" {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid redirect uri %s", redirectURI))
+		}
+	}
+
+	if accessTokenExpiryHour < 0 || refreshTokenExpiryHour < 0 {
+		return status.Error(codes.InvalidArgument, "Token lifetimes can not be negative")
+	}
+
+	return nil
+}
+
+func (uc ClientUsecase) Create(ctx context.Context, req domain.Client) (domain.CreateClientResponse, error) {
+	var res domain.CreateClientResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if req.Name == "" {
+		return res, status.Error(codes.InvalidArgument, "Name is required")
+	}
+
+	if len(req.GrantTypes) == 0 {
+		return res, status.Error(codes.InvalidArgument, "At least one grant type is required")
+	}
+
+	err := validateClient(req.GrantTypes, req.RedirectURIs, req.AccessTokenExpiryHour, req.RefreshTokenExpiryHour)
+	if err != nil {
+		return res, err
+	}
+
+	if req.HasGrantType(domain.GRANT_TYPE_AUTHORIZATION_CODE) && len(req.RedirectURIs) == 0 {
+		return res, status.Error(codes.InvalidArgument, "At least one redirect uri is required for the authorization code grant")
+	}
+
+	if req.IsPublic && req.HasGrantType(domain.GRANT_TYPE_CLIENT_CREDENTIALS) {
+		return res, status.Error(codes.InvalidArgument, "Public clients can not use the client credentials grant")
+	}
+
+	clientID, err := oauth.GenerateClientID()
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	client := req
+	client.ID = primitive.NewObjectID()
+	client.ClientID = clientID
+
+	var clientSecret string
+	if !client.IsPublic {
+		clientSecret, client.SecretHash, err = generateClientSecret()
+		if err != nil {
+			return res, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	err = uc.clr.Create(ctx, client)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	client.SecretHash = ""
+	res = domain.CreateClientResponse{
+		Client:       client,
+		ClientSecret: clientSecret,
+	}
+
+	return res, nil
+}
+
+func (uc ClientUsecase) GetAll(ctx context.Context) ([]domain.Client, error) {
+	var res []domain.Client
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	res, err := uc.clr.GetAll(ctx)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func (uc ClientUsecase) getByClientID(ctx context.Context, clientID string) (domain.Client, error) {
+	client, err := uc.clr.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return client, status.Error(codes.NotFound, fmt.Sprintf("Client with id %s not found", clientID))
+		}
+
+		return client, status.Error(codes.Internal, err.Error())
+	}
+
+	return client, nil
+}
+
+func (uc ClientUsecase) Update(ctx context.Context, req domain.UpdateClient) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	client, err := uc.getByClientID(ctx, req.ClientID)
+	if err != nil {
+		return err
+	}
+
+	if req.GrantTypes != nil {
+		if len(*req.GrantTypes) == 0 {
+			return status.Error(codes.InvalidArgument, "At least one grant type is required")
+		}
+
+		client.GrantTypes = *req.GrantTypes
+	}
+
+	if req.RedirectURIs != nil {
+		client.RedirectURIs = *req.RedirectURIs
+	}
+
+	err = validateClient(client.GrantTypes, client.RedirectURIs, req.AccessTokenExpiryHour, req.RefreshTokenExpiryHour)
+	if err != nil {
+		return err
+	}
+
+	if client.HasGrantType(domain.GRANT_TYPE_AUTHORIZATION_CODE) && len(client.RedirectURIs) == 0 {
+		return status.Error(codes.InvalidArgument, "At least one redirect uri is required for the authorization code grant")
+	}
+
+	if client.IsPublic && client.HasGrantType(domain.GRANT_TYPE_CLIENT_CREDENTIALS) {
+		return status.Error(codes.InvalidArgument, "Public clients can not use the client credentials grant")
+	}
+
+	err = uc.clr.Update(ctx, req)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (uc ClientUsecase) RotateSecret(ctx context.Context, clientID string) (domain.RotateClientSecretResponse, error) {
+	var res domain.RotateClientSecretResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	client, err := uc.getByClientID(ctx, clientID)
+	if err != nil {
+		return res, err
+	}
+
+	if client.IsPublic {
+		return res, status.Error(codes.FailedPrecondition, "Public clients have no secret")
+	}
+
+	clientSecret, secretHash, err := generateClientSecret()
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.clr.UpdateSecret(ctx, clientID, secretHash)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	res = domain.RotateClientSecretResponse{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+
+	return res, nil
+}
+
+func (uc ClientUsecase) Delete(ctx context.Context, clientID string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	_, err := uc.getByClientID(ctx, clientID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.clr.Delete(ctx, clientID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func list(values ...string) *[]string {
+	return &values
+}
+
+func TestClientUpdate(t *testing.T) {
+	env := newTestEnv(t)
+	uc := NewClientUsecase(env.clr, env.timeout())
+
+	created, err := uc.Create(context.Background(), domain.Client{
+		Name:         "Backoffice",
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE, domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+		RedirectURIs: []string{"https://backoffice.example.com/callback"},
+		Scopes:       []string{"users:read"},
+	})
+	require.NoError(t, err)
+
+	clientID := created.Client.ClientID
+
+	err = uc.Update(context.Background(), domain.UpdateClient{ClientID: clientID, GrantTypes: list()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "a client keeps a grant type")
+
+	err = uc.Update(context.Background(), domain.UpdateClient{ClientID: clientID, RedirectURIs: list()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the authorization code grant needs a redirect uri")
+
+	err = uc.Update(context.Background(), domain.UpdateClient{ClientID: clientID, GrantTypes: list("unknown")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Lists left out stay as they are
+	err = uc.Update(context.Background(), domain.UpdateClient{ClientID: clientID, Name: "Back office"})
+	require.NoError(t, err)
+
+	client, err := env.clr.GetByClientID(context.Background(), clientID)
+	require.NoError(t, err)
+	assert.Equal(t, "Back office", client.Name)
+	assert.Len(t, client.RedirectURIs, 1)
+	assert.Len(t, client.Scopes, 1)
+
+	// Empty lists clear them
+	err = uc.Update(context.Background(), domain.UpdateClient{
+		ClientID:     clientID,
+		GrantTypes:   list(domain.GRANT_TYPE_CLIENT_CREDENTIALS),
+		RedirectURIs: list(),
+		Scopes:       list(),
+	})
+	require.NoError(t, err)
+
+	client, err = env.clr.GetByClientID(context.Background(), clientID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS}, client.GrantTypes)
+	assert.Empty(t, client.RedirectURIs)
+	assert.Empty(t, client.Scopes)
+}
+
+func TestClientCreate(t *testing.T) {
+	env := newTestEnv(t)
+	uc := NewClientUsecase(env.clr, env.timeout())
+
+	t.Run("success", func(t *testing.T) {
+		res, err := uc.Create(context.Background(), domain.Client{
+			Name:       "Service",
+			GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.Client.ClientID)
+		assert.NotEmpty(t, res.ClientSecret)
+		assert.Empty(t, res.Client.SecretHash)
+
+		// Only a hash of the secret is stored
+		stored, err := env.clr.GetByClientID(context.Background(), res.Client.ClientID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, stored.SecretHash)
+		assert.NotContains(t, stored.SecretHash, res.ClientSecret)
+
+		res, err = uc.Create(context.Background(), domain.Client{
+			Name:         "Single page app",
+			IsPublic:     true,
+			GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+			RedirectURIs: []string{"https://app.example.com/callback"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.ClientSecret)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tests := map[string]domain.Client{
+			"missing name":              {GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS}},
+			"missing grant type":        {Name: "Client"},
+			"unsupported grant type":    {Name: "Client", GrantTypes: []string{"password"}},
+			"missing redirect uri":      {Name: "Client", GrantTypes: []string{domain.GRANT_TYPE_AUTHORIZATION_CODE}},
+			"relative redirect uri":     {Name: "Client", GrantTypes: []string{domain.GRANT_TYPE_AUTHORIZATION_CODE}, RedirectURIs: []string{"/callback"}},
+			"redirect uri fragment":     {Name: "Client", GrantTypes: []string{domain.GRANT_TYPE_AUTHORIZATION_CODE}, RedirectURIs: []string{"https://app.example.com/#callback"}},
+			"negative token lifetime":   {Name: "Client", GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS}, AccessTokenExpiryHour: -1},
+			"public client credentials": {Name: "Client", IsPublic: true, GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS}},
+		}
+
+		for name, client := range tests {
+			_, err := uc.Create(context.Background(), client)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+}
+
+func TestVerifyClient(t *testing.T) {
+	env := newTestEnv(t)
+	oauthUsecase := env.oauthUsecase()
+
+	service, secret := env.addClient(t, domain.Client{
+		Name:       "Service",
+		GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+	})
+
+	spa, _ := env.addClient(t, domain.Client{
+		Name:         "Single page app",
+		IsPublic:     true,
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client, err := oauthUsecase.verifyClient(context.Background(), service.ClientID, secret)
+		require.NoError(t, err)
+		assert.Equal(t, service.ClientID, client.ClientID)
+
+		// Public clients only prove their id
+		_, err = oauthUsecase.verifyClient(context.Background(), spa.ClientID, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := oauthUsecase.verifyClient(context.Background(), service.ClientID, "wrong")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "wrong secret")
+
+		_, err = oauthUsecase.verifyClient(context.Background(), service.ClientID, "")
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "missing secret")
+
+		_, err = oauthUsecase.verifyClient(context.Background(), "unknown", secret)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "unknown client")
+	})
+}
+
+func TestClientRotateSecret(t *testing.T) {
+	env := newTestEnv(t)
+	uc := NewClientUsecase(env.clr, env.timeout())
+	oauthUsecase := env.oauthUsecase()
+
+	service, secret := env.addClient(t, domain.Client{
+		Name:       "Service",
+		GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+	})
+
+	spa, _ := env.addClient(t, domain.Client{
+		Name:         "Single page app",
+		IsPublic:     true,
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://app.example.com/callback"},
+	})
+
+	res, err := uc.RotateSecret(context.Background(), service.ClientID)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, res.ClientSecret)
+
+	// The previous secret stops working at once
+	_, err = oauthUsecase.verifyClient(context.Background(), service.ClientID, secret)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = oauthUsecase.verifyClient(context.Background(), service.ClientID, res.ClientSecret)
+	assert.NoError(t, err)
+
+	_, err = uc.RotateSecret(context.Background(), spa.ClientID)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = uc.RotateSecret(context.Background(), "unknown")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,732 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/cache"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/mailer"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/rbac"
+	"github.com/digisata/auth-service/pkg/totp"
+	memoryRepo "github.com/digisata/auth-service/repository/memory"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// The fakes below keep what the mongo repositories would store in maps, so the
+// usecases can be tested without a database
+
+type fakeUserRepository struct {
+	mu    sync.Mutex
+	users map[string]domain.User
+}
+
+func (r *fakeUserRepository) Create(ctx context.Context, req domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.users[req.ID.Hex()] = req
+
+	return nil
+}
+
+func (r *fakeUserRepository) GetAll(ctx context.Context, req domain.GetAllUserRequest) ([]domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users := []domain.User{}
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (r *fakeUserRepository) GetByEmail(ctx context.Context, email string) (domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+
+	return domain.User{}, mongo.ErrNoDocuments
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id string) (domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return user, mongo.ErrNoDocuments
+	}
+
+	return user, nil
+}
+
+func (r *fakeUserRepository) update(id string, fn func(user *domain.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+
+	fn(&user)
+	r.users[id] = user
+
+	return nil
+}
+
+func (r *fakeUserRepository) Update(ctx context.Context, req domain.UpdateUser) error {
+	return r.update(req.ID.Hex(), func(user *domain.User) {
+		if req.Name != "" {
+			user.Name = req.Name
+		}
+
+		if req.Role != 0 {
+			user.Role = req.Role
+		}
+
+		if req.Note != "" {
+			user.Note = req.Note
+		}
+
+		user.IsActive = req.IsActive
+	})
+}
+
+func (r *fakeUserRepository) Delete(ctx context.Context, req domain.DeleteUser) error {
+	return r.update(req.ID.Hex(), func(user *domain.User) {
+		user.IsActive = false
+		user.DeletedAt = time.Now().Unix()
+	})
+}
+
+func (r *fakeUserRepository) UpdateMFA(ctx context.Context, id string, req domain.UpdateMFA) error {
+	return r.update(id, func(user *domain.User) {
+		user.TOTPSecret = req.TOTPSecret
+		user.MFAEnabled = req.MFAEnabled
+		user.RecoveryCodes = req.RecoveryCodes
+	})
+}
+
+func (r *fakeUserRepository) ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (bool, error) {
+	consumed := false
+
+	err := r.update(id, func(user *domain.User) {
+		for i, val := range user.RecoveryCodes {
+			if val == codeHash {
+				user.RecoveryCodes = append(user.RecoveryCodes[:i:i], user.RecoveryCodes[i+1:]...)
+				consumed = true
+				return
+			}
+		}
+	})
+
+	return consumed, err
+}
+
+func (r *fakeUserRepository) SetRecoveryCodes(ctx context.Context, id string, codeHashes []string) error {
+	return r.update(id, func(user *domain.User) {
+		user.RecoveryCodes = codeHashes
+	})
+}
+
+func (r *fakeUserRepository) SetEmailVerified(ctx context.Context, id string) error {
+	return r.update(id, func(user *domain.User) {
+		user.EmailVerified = true
+	})
+}
+
+func (r *fakeUserRepository) UpdatePassword(ctx context.Context, id, password string) error {
+	return r.update(id, func(user *domain.User) {
+		user.Password = password
+	})
+}
+
+func (r *fakeUserRepository) GetTokenEpoch(ctx context.Context, id string) (int64, error) {
+	user, err := r.GetByID(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return user.TokenEpoch, nil
+}
+
+func (r *fakeUserRepository) IncrementTokenEpoch(ctx context.Context, id string) (int64, error) {
+	err := r.update(id, func(user *domain.User) {
+		user.TokenEpoch++
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return r.GetTokenEpoch(ctx, id)
+}
+
+// fakeProfileRepository reads the users of the user repository as profiles
+type fakeProfileRepository struct {
+	users *fakeUserRepository
+}
+
+func (r fakeProfileRepository) GetByID(ctx context.Context, id string) (domain.Profile, error) {
+	user, err := r.users.GetByID(ctx, id)
+	if err != nil {
+		return domain.Profile{}, err
+	}
+
+	return domain.Profile{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		Password:  user.Password,
+		DeletedAt: user.DeletedAt,
+	}, nil
+}
+
+func (r fakeProfileRepository) ChangePassword(ctx context.Context, id, newPassword string) error {
+	return r.users.UpdatePassword(ctx, id, newPassword)
+}
+
+type fakePasswordHistoryRepository struct {
+	mu        sync.Mutex
+	histories map[string][]string
+}
+
+func (r *fakePasswordHistoryRepository) GetByUserID(ctx context.Context, userID string) (domain.PasswordHistory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	passwords, ok := r.histories[userID]
+	if !ok {
+		return domain.PasswordHistory{}, mongo.ErrNoDocuments
+	}
+
+	return domain.PasswordHistory{Passwords: passwords}, nil
+}
+
+func (r *fakePasswordHistoryRepository) Push(ctx context.Context, userID, password string, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	passwords := append(r.histories[userID], password)
+	if len(passwords) > keep {
+		passwords = passwords[len(passwords)-keep:]
+	}
+
+	r.histories[userID] = passwords
+
+	return nil
+}
+
+type fakeSessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]domain.Session
+}
+
+func (r *fakeSessionRepository) Create(ctx context.Context, req domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[req.ID.Hex()] = req
+
+	return nil
+}
+
+func (r *fakeSessionRepository) GetByID(ctx context.Context, id string) (domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[id]
+	if !ok {
+		return session, mongo.ErrNoDocuments
+	}
+
+	return session, nil
+}
+
+func (r *fakeSessionRepository) GetActiveByUserID(ctx context.Context, userID string) ([]domain.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessions := []domain.Session{}
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == 0 {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (r *fakeSessionRepository) Touch(ctx context.Context, id string, expiresAt int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session := r.sessions[id]
+	session.ExpiresAt = expiresAt
+	r.sessions[id] = session
+
+	return nil
+}
+
+func (r *fakeSessionRepository) Revoke(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session := r.sessions[id]
+	session.RevokedAt = time.Now().Unix()
+	r.sessions[id] = session
+
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, session := range r.sessions {
+		if session.UserID == userID {
+			session.RevokedAt = time.Now().Unix()
+			r.sessions[id] = session
+		}
+	}
+
+	return nil
+}
+
+type fakeSecurityEventRepository struct {
+	mu     sync.Mutex
+	events []domain.SecurityEvent
+}
+
+func (r *fakeSecurityEventRepository) Create(ctx context.Context, req domain.SecurityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, req)
+
+	return nil
+}
+
+type fakeClientRepository struct {
+	mu      sync.Mutex
+	clients map[string]domain.Client
+}
+
+func (r *fakeClientRepository) Create(ctx context.Context, req domain.Client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clients[req.ClientID] = req
+
+	return nil
+}
+
+func (r *fakeClientRepository) GetAll(ctx context.Context) ([]domain.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := []domain.Client{}
+	for _, client := range r.clients {
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+func (r *fakeClientRepository) GetByClientID(ctx context.Context, clientID string) (domain.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, ok := r.clients[clientID]
+	if !ok {
+		return client, mongo.ErrNoDocuments
+	}
+
+	return client, nil
+}
+
+func (r *fakeClientRepository) Update(ctx context.Context, req domain.UpdateClient) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client := r.clients[req.ClientID]
+	if req.Name != "" {
+		client.Name = req.Name
+	}
+
+	if req.GrantTypes != nil {
+		client.GrantTypes = *req.GrantTypes
+	}
+
+	if req.RedirectURIs != nil {
+		client.RedirectURIs = *req.RedirectURIs
+	}
+
+	if req.Scopes != nil {
+		client.Scopes = *req.Scopes
+	}
+
+	r.clients[req.ClientID] = client
+
+	return nil
+}
+
+func (r *fakeClientRepository) UpdateSecret(ctx context.Context, clientID, secretHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client := r.clients[clientID]
+	client.SecretHash = secretHash
+	r.clients[clientID] = client
+
+	return nil
+}
+
+func (r *fakeClientRepository) Delete(ctx context.Context, clientID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.clients, clientID)
+
+	return nil
+}
+
+// testEnv wires the fakes, an in-memory cache and token store and a signing
+// keyring the way main does
+type testEnv struct {
+	cfg *bootstrap.Config
+	jwt *jwtio.JSONWebToken
+	ur  *fakeUserRepository
+	phr *fakePasswordHistoryRepository
+	sr  *fakeSessionRepository
+	ser *fakeSecurityEventRepository
+	clr *fakeClientRepository
+	rr  *fakeRoleRepository
+	pr  *fakePermissionRepository
+	cr  CacheRepository
+	// roles resolves role codes out of rr, the built-in roles are seeded
+	roles *rbac.Resolver
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	memory := cache.NewMemory(cache.Config{}, nil)
+	t.Cleanup(memory.Close)
+
+	cfg := &bootstrap.Config{
+		ContextTimeout: 5,
+		Jwt: jwtio.Config{
+			AccessTokenExpiryHour:  1,
+			RefreshTokenExpiryHour: 24,
+			AccessTokenSecret:      "access-secret",
+			RefreshTokenSecret:     "refresh-secret",
+			Issuer:                 "https://auth.example.com",
+		},
+		MFA: totp.Config{
+			Issuer:                "auth-service",
+			EncryptionKey:         "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=",
+			ChallengeExpirySecond: 300,
+		},
+		OAuth: oauth.Config{
+			AuthorizationCodeExpirySecond: 60,
+		},
+		Password: password.Config{
+			MinLength: 8,
+			Hashing:   password.HashConfig{BcryptCost: bcrypt.MinCost},
+		},
+	}
+
+	env := &testEnv{
+		cfg: cfg,
+		ur:  &fakeUserRepository{users: map[string]domain.User{}},
+		phr: &fakePasswordHistoryRepository{histories: map[string][]string{}},
+		sr:  &fakeSessionRepository{sessions: map[string]domain.Session{}},
+		ser: &fakeSecurityEventRepository{},
+		clr: &fakeClientRepository{clients: map[string]domain.Client{}},
+		rr:  &fakeRoleRepository{},
+		pr:  &fakePermissionRepository{permissions: map[string]domain.Permission{}},
+		cr:  memoryRepo.NewCacheRepository(memory),
+	}
+
+	env.roles = rbac.NewResolver(NewRoleLoader(env.rr), time.Minute, nil)
+	require.NoError(t, env.roleUsecase().Seed(context.Background()))
+
+	j, err := jwtio.NewJSONWebToken(&cfg.Jwt, jwtio.NewMemoryTokenStore(memory), NewTokenEpochSource(env.ur))
+	require.NoError(t, err)
+
+	env.jwt = j
+
+	return env
+}
+
+func (env *testEnv) roleUsecase() *RoleUsecase {
+	return NewRoleUsecase(env.cfg, env.rr, env.pr, env.roles, env.timeout())
+}
+
+func (env *testEnv) passwords(t *testing.T) (*password.Policy, *password.Hasher) {
+	policy, err := password.NewPolicy(env.cfg.Password)
+	require.NoError(t, err)
+
+	hasher, err := password.NewHasher(env.cfg.Password.Hashing)
+	require.NoError(t, err)
+
+	return policy, hasher
+}
+
+func (env *testEnv) userUsecase(t *testing.T) *UserUsecase {
+	policy, hasher := env.passwords(t)
+
+	return NewUserUsecase(env.jwt, env.cfg, env.ur, env.phr, env.sr, env.ser, env.clr, env.cr, env.roles, policy, hasher, zap.NewNop().Sugar(), env.timeout())
+}
+
+func (env *testEnv) accountUsecase(t *testing.T, mail mailer.Mailer) *AccountUsecase {
+	policy, hasher := env.passwords(t)
+
+	return NewAccountUsecase(env.jwt, env.cfg, env.ur, env.phr, env.sr, env.cr, mail, env.roles, policy, hasher, zap.NewNop().Sugar(), env.timeout())
+}
+
+func (env *testEnv) profileUsecase(t *testing.T) *ProfileUsecase {
+	policy, hasher := env.passwords(t)
+
+	return NewProfileUsecase(env.jwt, env.cfg, fakeProfileRepository{users: env.ur}, env.phr, env.sr, env.cr, env.roles, policy, hasher, env.timeout())
+}
+
+func (env *testEnv) oauthUsecase() *OAuthUsecase {
+	return NewOAuthUsecase(env.jwt, env.cfg, env.ur, env.clr, env.sr, env.cr, env.timeout())
+}
+
+// addClient registers a client and returns it with its secret, which is empty
+// for public clients
+func (env *testEnv) addClient(t *testing.T, client domain.Client) (domain.Client, string) {
+	res, err := NewClientUsecase(env.clr, env.timeout()).Create(context.Background(), client)
+	require.NoError(t, err)
+
+	client, err = env.clr.GetByClientID(context.Background(), res.Client.ClientID)
+	require.NoError(t, err)
+
+	return client, res.ClientSecret
+}
+
+// addUser stores an active user with the given role
+func (env *testEnv) addUser(t *testing.T, role domain.UserRole) domain.User {
+	user := domain.User{
+		ID:       primitive.NewObjectID(),
+		Name:     "Test",
+		Role:     int8(role),
+		Email:    primitive.NewObjectID().Hex() + "@example.com",
+		IsActive: true,
+	}
+
+	require.NoError(t, env.ur.Create(context.Background(), user))
+
+	return user
+}
+
+func (env *testEnv) timeout() time.Duration {
+	return time.Duration(env.cfg.ContextTimeout) * time.Second
+}
+
+// duplicateKeyError is what mongo answers a write breaking a unique index with
+var duplicateKeyError = mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}
+
+type fakeRoleRepository struct {
+	mu    sync.Mutex
+	roles []domain.Role
+	// beforeCreate runs ahead of every Create, to let another role in first
+	beforeCreate func()
+}
+
+func (r *fakeRoleRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (r *fakeRoleRepository) Create(ctx context.Context, req domain.Role) error {
+	if r.beforeCreate != nil {
+		r.beforeCreate()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, role := range r.roles {
+		if role.Code == req.Code || role.Name == req.Name {
+			return duplicateKeyError
+		}
+	}
+
+	r.roles = append(r.roles, req)
+
+	return nil
+}
+
+func (r *fakeRoleRepository) GetAll(ctx context.Context) ([]domain.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.Role{}, r.roles...), nil
+}
+
+func (r *fakeRoleRepository) find(match func(role domain.Role) bool) (domain.Role, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, role := range r.roles {
+		if match(role) {
+			return role, nil
+		}
+	}
+
+	return domain.Role{}, mongo.ErrNoDocuments
+}
+
+func (r *fakeRoleRepository) GetByID(ctx context.Context, id string) (domain.Role, error) {
+	return r.find(func(role domain.Role) bool { return role.ID.Hex() == id })
+}
+
+func (r *fakeRoleRepository) GetByName(ctx context.Context, name string) (domain.Role, error) {
+	return r.find(func(role domain.Role) bool { return role.Name == name })
+}
+
+func (r *fakeRoleRepository) update(id primitive.ObjectID, fn func(role *domain.Role)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.roles {
+		if r.roles[i].ID == id {
+			fn(&r.roles[i])
+		}
+	}
+
+	return nil
+}
+
+func (r *fakeRoleRepository) Update(ctx context.Context, req domain.UpdateRole) error {
+	return r.update(req.ID, func(role *domain.Role) {
+		if req.Name != "" {
+			role.Name = req.Name
+		}
+
+		if req.Description != "" {
+			role.Description = req.Description
+		}
+	})
+}
+
+func (r *fakeRoleRepository) AddPermission(ctx context.Context, id primitive.ObjectID, permission string) error {
+	return r.update(id, func(role *domain.Role) {
+		for _, val := range role.Permissions {
+			if val == permission {
+				return
+			}
+		}
+
+		role.Permissions = append(role.Permissions, permission)
+	})
+}
+
+func (r *fakeRoleRepository) RemovePermission(ctx context.Context, id primitive.ObjectID, permission string) error {
+	return r.update(id, func(role *domain.Role) {
+		permissions := []string{}
+		for _, val := range role.Permissions {
+			if val != permission {
+				permissions = append(permissions, val)
+			}
+		}
+
+		role.Permissions = permissions
+	})
+}
+
+type fakePermissionRepository struct {
+	mu          sync.Mutex
+	permissions map[string]domain.Permission
+}
+
+func (r *fakePermissionRepository) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (r *fakePermissionRepository) GetAll(ctx context.Context) ([]domain.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	permissions := []domain.Permission{}
+	for _, permission := range r.permissions {
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (r *fakePermissionRepository) GetByName(ctx context.Context, name string) (domain.Permission, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	permission, ok := r.permissions[name]
+	if !ok {
+		return permission, mongo.ErrNoDocuments
+	}
+
+	return permission, nil
+}
+
+func (r *fakePermissionRepository) Upsert(ctx context.Context, req domain.Permission) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.permissions[req.Name] = req
+
+	return nil
+}
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+	// err fails every delivery when set
+	err error
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, msg)
+
+	return nil
+}
+
+// messages waits until n emails went out, they are sent in the background
+func (m *fakeMailer) messages(t *testing.T, n int) []mailer.Message {
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		return len(m.sent) >= n
+	}, time.Second, time.Millisecond)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]mailer.Message(nil), m.sent...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/digisata/auth-service/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type (
@@ -14,6 +15,13 @@ type (
 		GetByID(ctx context.Context, id string) (domain.User, error)
 		Update(ctx context.Context, req domain.UpdateUser) error
 		Delete(ctx context.Context, req domain.DeleteUser) error
+		UpdateMFA(ctx context.Context, id string, req domain.UpdateMFA) error
+		ConsumeRecoveryCode(ctx context.Context, id, codeHash string) (bool, error)
+		SetRecoveryCodes(ctx context.Context, id string, codeHashes []string) error
+		SetEmailVerified(ctx context.Context, id string) error
+		UpdatePassword(ctx context.Context, id, password string) error
+		GetTokenEpoch(ctx context.Context, id string) (int64, error)
+		IncrementTokenEpoch(ctx context.Context, id string) (int64, error)
 	}
 
 	ProfileRepository interface {
@@ -21,9 +29,55 @@ type (
 		ChangePassword(ctx context.Context, id, newPassword string) error
 	}
 
+	PasswordHistoryRepository interface {
+		GetByUserID(ctx context.Context, userID string) (domain.PasswordHistory, error)
+		Push(ctx context.Context, userID, password string, keep int) error
+	}
+
+	SecurityEventRepository interface {
+		Create(ctx context.Context, req domain.SecurityEvent) error
+	}
+
+	SessionRepository interface {
+		Create(ctx context.Context, req domain.Session) error
+		GetByID(ctx context.Context, id string) (domain.Session, error)
+		GetActiveByUserID(ctx context.Context, userID string) ([]domain.Session, error)
+		Touch(ctx context.Context, id string, expiresAt int64) error
+		Revoke(ctx context.Context, id string) error
+		RevokeByUserID(ctx context.Context, userID string) error
+	}
+
+	ClientRepository interface {
+		Create(ctx context.Context, req domain.Client) error
+		GetAll(ctx context.Context) ([]domain.Client, error)
+		GetByClientID(ctx context.Context, clientID string) (domain.Client, error)
+		Update(ctx context.Context, req domain.UpdateClient) error
+		UpdateSecret(ctx context.Context, clientID, secretHash string) error
+		Delete(ctx context.Context, clientID string) error
+	}
+
+	RoleRepository interface {
+		EnsureIndexes(ctx context.Context) error
+		Create(ctx context.Context, req domain.Role) error
+		GetAll(ctx context.Context) ([]domain.Role, error)
+		GetByID(ctx context.Context, id string) (domain.Role, error)
+		GetByName(ctx context.Context, name string) (domain.Role, error)
+		Update(ctx context.Context, req domain.UpdateRole) error
+		AddPermission(ctx context.Context, id primitive.ObjectID, permission string) error
+		RemovePermission(ctx context.Context, id primitive.ObjectID, permission string) error
+	}
+
+	PermissionRepository interface {
+		EnsureIndexes(ctx context.Context) error
+		GetAll(ctx context.Context) ([]domain.Permission, error)
+		GetByName(ctx context.Context, name string) (domain.Permission, error)
+		Upsert(ctx context.Context, req domain.Permission) error
+	}
+
 	CacheRepository interface {
 		Set(req domain.CacheItem) error
 		Get(key string) (domain.CacheItem, error)
 		Delete(key string) error
+		Increment(key string, exp int32) (int64, error)
 	}
 )
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/lockout"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loginGuard throttles password logins per account and per account+IP. It keys
+// on the submitted email whether or not a user has it, so a lock says nothing
+// about which accounts exist.
+type loginGuard struct {
+	cfg lockout.Config
+	cr  CacheRepository
+}
+
+func (g loginGuard) enabled() bool {
+	return g.cfg.WindowSecond > 0 && (g.cfg.MaxAttempts > 0 || g.cfg.MaxAttemptsPerIP > 0)
+}
+
+// scope names the counters of an email. Unlocking bumps the generation, which
+// orphans every counter and lock of the previous one, per IP ones included.
+func (g loginGuard) scope(email string) (string, error) {
+	account := oauth.HashToken(strings.ToLower(email))
+
+	generation := "0"
+
+	item, err := g.cr.Get(domain.LOGIN_GENERATION_KEY_PREFIX + account)
+	if err == nil {
+		generation = item.Value
+	} else if !errors.Is(err, domain.ErrCacheMiss) {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	return account + ":" + generation, nil
+}
+
+func (g loginGuard) isLocked(key string) (bool, error) {
+	_, err := g.cr.Get(domain.LOGIN_LOCK_KEY_PREFIX + key)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, domain.ErrCacheMiss) {
+		return false, nil
+	}
+
+	return false, status.Error(codes.Internal, err.Error())
+}
+
+// check fails while the account, or the account from this IP, is locked
+func (g loginGuard) check(email, ip string) error {
+	if !g.enabled() {
+		return nil
+	}
+
+	scope, err := g.scope(email)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range []string{scope, scope + ":" + ip} {
+		locked, err := g.isLocked(key)
+		if err != nil {
+			return err
+		}
+
+		if locked {
+			return status.Error(codes.ResourceExhausted, "Too many failed attempts, try again later")
+		}
+	}
+
+	return nil
+}
+
+// count records a failure for key and returns the failures within the sliding window
+func (g loginGuard) count(key string, now time.Time) (float64, error) {
+	index, elapsed := g.cfg.WindowIndex(now)
+	prefix := domain.LOGIN_FAILURES_KEY_PREFIX + key + ":"
+
+	current, err := g.cr.Increment(fmt.Sprintf("%s%d", prefix, index), int32(now.Add(2*g.cfg.Window()).Unix()))
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	var previous int64
+
+	item, err := g.cr.Get(fmt.Sprintf("%s%d", prefix, index-1))
+	if err == nil {
+		fmt.Sscan(item.Value, &previous)
+	} else if !errors.Is(err, domain.ErrCacheMiss) {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	return lockout.SlidingCount(previous, current, elapsed), nil
+}
+
+// lock locks key for a duration that grows with every lockout, and clears its
+// failures so the lock does not re-arm as soon as it expires
+func (g loginGuard) lock(key string, now time.Time) error {
+	lockouts, err := g.cr.Increment(domain.LOGIN_LOCKOUTS_KEY_PREFIX+key, int32(now.Add(time.Hour*time.Duration(g.cfg.ResetHour)).Unix()))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// An uncapped lock can outgrow the cache expiry
+	exp := now.Add(g.cfg.LockDuration(lockouts)).Unix()
+	if exp > math.MaxInt32 {
+		exp = math.MaxInt32
+	}
+
+	err = g.cr.Set(domain.CacheItem{
+		Key: domain.LOGIN_LOCK_KEY_PREFIX + key,
+		Exp: int32(exp),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return g.clear(key, now)
+}
+
+func (g loginGuard) clear(key string, now time.Time) error {
+	index, _ := g.cfg.WindowIndex(now)
+	prefix := domain.LOGIN_FAILURES_KEY_PREFIX + key + ":"
+
+	for _, i := range []int64{index - 1, index} {
+		err := g.cr.Delete(fmt.Sprintf("%s%d", prefix, i))
+		if err != nil && !errors.Is(err, domain.ErrCacheMiss) {
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// fail records a failed login and locks whatever crossed its threshold
+func (g loginGuard) fail(email, ip string) error {
+	if !g.enabled() {
+		return nil
+	}
+
+	scope, err := g.scope(email)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	thresholds := map[string]int{
+		scope:            g.cfg.MaxAttempts,
+		scope + ":" + ip: g.cfg.MaxAttemptsPerIP,
+	}
+
+	for key, threshold := range thresholds {
+		if threshold <= 0 {
+			continue
+		}
+
+		failures, err := g.count(key, now)
+		if err != nil {
+			return err
+		}
+
+		if failures >= float64(threshold) {
+			err = g.lock(key, now)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// succeed forgives the failures from the IP that just proved the password. The
+// failures from everywhere else keep counting towards the account lock.
+func (g loginGuard) succeed(email, ip string) error {
+	if !g.enabled() {
+		return nil
+	}
+
+	scope, err := g.scope(email)
+	if err != nil {
+		return err
+	}
+
+	return g.clear(scope+":"+ip, time.Now())
+}
+
+// unlock lifts every lock and forgets every failure of the email
+func (g loginGuard) unlock(email string) error {
+	account := oauth.HashToken(strings.ToLower(email))
+
+	_, err := g.cr.Increment(domain.LOGIN_GENERATION_KEY_PREFIX+account, 0)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/encryption"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/digisata/auth-service/pkg/totp"
+	"github.com/digisata/auth-service/pkg/utils"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MFAUsecase struct {
+	jwt     *jwtio.JSONWebToken
+	cfg     *bootstrap.Config
+	ur      UserRepository
+	sr      SessionRepository
+	cr      CacheRepository
+	cipher  *encryption.Cipher
+	guard   loginGuard
+	timeout time.Duration
+}
+
+func NewMFAUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur UserRepository, sr SessionRepository, cr CacheRepository, cipher *encryption.Cipher, timeout time.Duration) *MFAUsecase {
+	return &MFAUsecase{
+		jwt:     jwt,
+		cfg:     cfg,
+		ur:      ur,
+		sr:      sr,
+		cr:      cr,
+		cipher:  cipher,
+		guard:   loginGuard{cfg: cfg.Lockout, cr: cr},
+		timeout: timeout,
+	}
+}
+
+// createMFAChallenge is returned by the login flow instead of tokens when the user
+// has MFA enabled. The challenge token is only good for VerifyMFA, which issues
+// the tokens for audience.
+func createMFAChallenge(cfg *bootstrap.Config, cr CacheRepository, user domain.User, audience string) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+
+	token, err := oauth.GenerateToken()
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	exp := time.Now().Add(time.Second * time.Duration(cfg.MFA.ChallengeExpirySecond)).Unix()
+
+	err = setMFAChallenge(cr, token, domain.MFAChallenge{
+		UserID:   user.ID.Hex(),
+		Audience: audience,
+		Exp:      exp,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	res = domain.AuthResponse{
+		MFARequired: true,
+		MFAToken:    token,
+	}
+
+	return res, nil
+}
+
+func setMFAChallenge(cr CacheRepository, token string, challenge domain.MFAChallenge) error {
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = cr.Set(domain.CacheItem{
+		Key:   domain.MFA_CHALLENGE_KEY_PREFIX + oauth.HashToken(token),
+		Value: string(value),
+		Exp:   int32(challenge.Exp),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (uc MFAUsecase) getUser(ctx context.Context, userID string) (domain.User, error) {
+	user, err := uc.ur.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return user, status.Error(codes.NotFound, fmt.Sprintf("User with id %s not found", userID))
+		}
+
+		return user, status.Error(codes.Internal, err.Error())
+	}
+
+	return user, nil
+}
+
+// verifyTOTP checks a code against the stored secret of the user. A code that
+// was accepted once is refused for the rest of its validity window.
+func (uc MFAUsecase) verifyTOTP(user domain.User, code string) error {
+	secret, err := uc.cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	now := time.Now()
+
+	step, ok := totp.Validate(secret, code, now)
+	if !ok {
+		return status.Error(codes.InvalidArgument, "Invalid code")
+	}
+
+	key := fmt.Sprintf("%s%s:%d", domain.TOTP_USED_KEY_PREFIX, user.ID.Hex(), step)
+
+	// Only the first use counts the step up to 1, concurrent uses of the same code
+	// see a higher count. Long enough to outlive every step Validate still accepts.
+	uses, err := uc.cr.Increment(key, int32(now.Add(3*30*time.Second).Unix()))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if uses > 1 {
+		return status.Error(codes.InvalidArgument, "Invalid code")
+	}
+
+	return nil
+}
+
+// generateRecoveryCodes returns new recovery codes and the hashes to store
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes, err := totp.GenerateRecoveryCodes(totp.RecoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, oauth.HashToken(totp.NormalizeRecoveryCode(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+// verifyRecoveryCode spends one recovery code of the user
+func (uc MFAUsecase) verifyRecoveryCode(ctx context.Context, user domain.User, code string) error {
+	codeHash := oauth.HashToken(totp.NormalizeRecoveryCode(code))
+
+	ok, err := uc.ur.ConsumeRecoveryCode(ctx, user.ID.Hex(), codeHash)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if !ok {
+		return status.Error(codes.InvalidArgument, "Invalid code")
+	}
+
+	return nil
+}
+
+func (uc MFAUsecase) EnrollTOTP(ctx context.Context) (domain.EnrollTOTPResponse, error) {
+	var res domain.EnrollTOTPResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return res, err
+	}
+
+	if user.MFAEnabled {
+		return res, status.Error(codes.FailedPrecondition, "MFA is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	encryptedSecret, err := uc.cipher.Encrypt(secret)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	// The secret stays pending until ConfirmTOTP proves the app was set up
+	err = uc.ur.UpdateMFA(ctx, userID, domain.UpdateMFA{
+		TOTPSecret:    encryptedSecret,
+		MFAEnabled:    false,
+		RecoveryCodes: recoveryCodeHashes,
+	})
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	res = domain.EnrollTOTPResponse{
+		Secret:        secret,
+		URI:           totp.URI(uc.cfg.MFA.Issuer, user.Email, secret),
+		RecoveryCodes: recoveryCodes,
+	}
+
+	return res, nil
+}
+
+func (uc MFAUsecase) ConfirmTOTP(ctx context.Context, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.MFAEnabled {
+		return status.Error(codes.FailedPrecondition, "MFA is already enabled")
+	}
+
+	if user.TOTPSecret == "" {
+		return status.Error(codes.FailedPrecondition, "TOTP is not enrolled")
+	}
+
+	err = uc.verifyTOTP(user, code)
+	if err != nil {
+		return err
+	}
+
+	err = uc.ur.UpdateMFA(ctx, userID, domain.UpdateMFA{
+		TOTPSecret:    user.TOTPSecret,
+		MFAEnabled:    true,
+		RecoveryCodes: user.RecoveryCodes,
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (uc MFAUsecase) DisableTOTP(ctx context.Context, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.MFAEnabled {
+		return status.Error(codes.FailedPrecondition, "MFA is not enabled")
+	}
+
+	err = uc.verifyTOTP(user, code)
+	if err != nil {
+		return err
+	}
+
+	err = uc.ur.UpdateMFA(ctx, userID, domain.UpdateMFA{})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user with a new set
+func (uc MFAUsecase) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	user, err := uc.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.MFAEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "MFA is not enabled")
+	}
+
+	err = uc.verifyTOTP(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.ur.SetRecoveryCodes(ctx, userID, recoveryCodeHashes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return recoveryCodes, nil
+}
+
+// VerifyMFA exchanges a login challenge and a TOTP or recovery code for the
+// access/refresh pair. Wrong codes count towards the lockout of the account like
+// wrong passwords, so starting new challenges gives no fresh guesses.
+func (uc MFAUsecase) VerifyMFA(ctx context.Context, req domain.VerifyMFARequest) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	key := domain.MFA_CHALLENGE_KEY_PREFIX + oauth.HashToken(req.MFAToken)
+
+	item, err := uc.cr.Get(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return res, status.Error(codes.Unauthenticated, "Invalid or expired MFA challenge")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	var challenge domain.MFAChallenge
+
+	err = json.Unmarshal([]byte(item.Value), &challenge)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	user, err := uc.ur.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return res, status.Error(codes.Unauthenticated, "Invalid or expired MFA challenge")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	if !user.IsActive || user.DeletedAt != 0 {
+		return res, status.Error(codes.Unauthenticated, "Your account has been deleted")
+	}
+
+	ip := utils.ClientIP(ctx)
+
+	err = uc.guard.check(user.Email, ip)
+	if err != nil {
+		return res, err
+	}
+
+	// Take an attempt before checking the code, so concurrent guesses against one
+	// challenge can not check more codes than it has attempts
+	attempts, err := uc.cr.Increment(domain.MFA_ATTEMPTS_KEY_PREFIX+oauth.HashToken(req.MFAToken), int32(challenge.Exp))
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	if attempts > int64(domain.MFA_MAX_ATTEMPTS) {
+		err = uc.cr.Delete(key)
+		if err != nil && !errors.Is(err, domain.ErrCacheMiss) {
+			return res, status.Error(codes.Internal, err.Error())
+		}
+
+		return res, status.Error(codes.Unauthenticated, "Invalid or expired MFA challenge")
+	}
+
+	if req.RecoveryCode != "" {
+		err = uc.verifyRecoveryCode(ctx, user, req.RecoveryCode)
+	} else {
+		err = uc.verifyTOTP(user, req.Code)
+	}
+	if err != nil {
+		// The challenge is burned with its last attempt
+		if attempts == int64(domain.MFA_MAX_ATTEMPTS) {
+			delErr := uc.cr.Delete(key)
+			if delErr != nil && !errors.Is(delErr, domain.ErrCacheMiss) {
+				return res, status.Error(codes.Internal, delErr.Error())
+			}
+		}
+
+		if status.Code(err) == codes.InvalidArgument {
+			failErr := uc.guard.fail(user.Email, ip)
+			if failErr != nil {
+				return res, failErr
+			}
+		}
+
+		return res, err
+	}
+
+	// Whoever deletes the challenge first owns it
+	err = uc.cr.Delete(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return res, status.Error(codes.Unauthenticated, "Invalid or expired MFA challenge")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.guard.succeed(user.Email, ip)
+	if err != nil {
+		return res, err
+	}
+
+	res, err = issueTokens(ctx, uc.jwt, uc.cfg, uc.sr, tokenRequest{
+		user:     user,
+		audience: challenge.Audience,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/encryption"
+	"github.com/digisata/auth-service/pkg/lockout"
+	"github.com/digisata/auth-service/pkg/portal"
+	"github.com/digisata/auth-service/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newMFAUsecase returns the usecase and a user with TOTP enabled and its secret
+func newMFAUsecase(t *testing.T, env *testEnv) (*MFAUsecase, domain.User, string) {
+	cipher, err := encryption.NewCipher(env.cfg.MFA.EncryptionKey)
+	require.NoError(t, err)
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt(secret)
+	require.NoError(t, err)
+
+	user := env.addUser(t, domain.CUSTOMER)
+	require.NoError(t, env.ur.UpdateMFA(context.Background(), user.ID.Hex(), domain.UpdateMFA{
+		TOTPSecret: encrypted,
+		MFAEnabled: true,
+	}))
+
+	user, err = env.ur.GetByID(context.Background(), user.ID.Hex())
+	require.NoError(t, err)
+
+	return NewMFAUsecase(env.jwt, env.cfg, env.ur, env.sr, env.cr, cipher, env.timeout()), user, secret
+}
+
+func TestVerifyMFA(t *testing.T) {
+	env := newTestEnv(t)
+	uc, user, secret := newMFAUsecase(t, env)
+
+	challenge, err := createMFAChallenge(env.cfg, env.cr, user, "")
+	require.NoError(t, err)
+
+	for i := 0; i < domain.MFA_MAX_ATTEMPTS-1; i++ {
+		_, err = uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "wrong"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	res, err := uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: code})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.AccessToken)
+	assert.NotEmpty(t, res.RefreshToken)
+
+	// The challenge is spent
+	_, err = uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: code})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestVerifyMFAConcurrentAttempts(t *testing.T) {
+	env := newTestEnv(t)
+	uc, user, secret := newMFAUsecase(t, env)
+
+	challenge, err := createMFAChallenge(env.cfg, env.cr, user, "")
+	require.NoError(t, err)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		checked int
+	)
+
+	for i := 0; i < 4*domain.MFA_MAX_ATTEMPTS; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "wrong"})
+			if status.Code(err) == codes.InvalidArgument {
+				mu.Lock()
+				checked++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, domain.MFA_MAX_ATTEMPTS, checked)
+
+	// Even the right code is refused once the attempts are used up
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: code})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// Wrong codes lock the account like wrong passwords, a fresh challenge from the
+// right password does not forgive them
+func TestVerifyMFACountsTowardsLockout(t *testing.T) {
+	env := newTestEnv(t)
+	env.cfg.Lockout = lockout.Config{
+		MaxAttempts:   domain.MFA_MAX_ATTEMPTS + 1,
+		WindowSecond:  600,
+		LockSecond:    60,
+		MaxLockSecond: 3600,
+		ResetHour:     24,
+	}
+
+	uc, user, secret := newMFAUsecase(t, env)
+
+	roleName, err := env.roles.RoleName(context.Background(), user.Role)
+	require.NoError(t, err)
+	env.cfg.Portals = portal.Config{"shop": {roleName}}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-horse-battery"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, env.ur.UpdatePassword(context.Background(), user.ID.Hex(), string(hash)))
+
+	login := func() (domain.AuthResponse, error) {
+		return env.userUsecase(t).Login(context.Background(), domain.LoginRequest{
+			Portal:   "shop",
+			Email:    user.Email,
+			Password: "correct-horse-battery",
+		})
+	}
+
+	challenge, err := login()
+	require.NoError(t, err)
+	require.True(t, challenge.MFARequired)
+
+	for i := 0; i < domain.MFA_MAX_ATTEMPTS; i++ {
+		_, err = uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "wrong"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+
+	challenge, err = login()
+	require.NoError(t, err)
+
+	_, err = uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "wrong"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Now even the right code and the right password are refused
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = uc.VerifyMFA(context.Background(), domain.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: code})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = login()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestVerifyTOTPConcurrentReplay(t *testing.T) {
+	env := newTestEnv(t)
+	uc, user, secret := newMFAUsecase(t, env)
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if uc.verifyTOTP(user, code) == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, accepted)
+}
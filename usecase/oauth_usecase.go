@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OAuthUsecase struct {
+	jwt     *jwtio.JSONWebToken
+	cfg     *bootstrap.Config
+	ur      UserRepository
+	clr     ClientRepository
+	sr      SessionRepository
+	cr      CacheRepository
+	timeout time.Duration
+}
+
+func NewOAuthUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur UserRepository, clr ClientRepository, sr SessionRepository, cr CacheRepository, timeout time.Duration) *OAuthUsecase {
+	return &OAuthUsecase{
+		jwt:     jwt,
+		cfg:     cfg,
+		ur:      ur,
+		clr:     clr,
+		sr:      sr,
+		cr:      cr,
+		timeout: timeout,
+	}
+}
+
+func (uc OAuthUsecase) getClient(ctx context.Context, clientID string) (domain.Client, bool, error) {
+	client, err := uc.clr.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return client, false, nil
+		}
+
+		return client, false, status.Error(codes.Internal, err.Error())
+	}
+
+	return client, true, nil
+}
+
+// verifyClient checks the credentials of a client. Public clients only prove
+// their id, confidential clients must present the secret matching the stored hash.
+func (uc OAuthUsecase) verifyClient(ctx context.Context, clientID, clientSecret string) (domain.Client, error) {
+	client, ok, err := uc.getClient(ctx, clientID)
+	if err != nil {
+		return client, err
+	}
+
+	if !ok {
+		return client, status.Error(codes.Unauthenticated, "Invalid client credentials")
+	}
+
+	if client.IsPublic {
+		return client, nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(clientSecret))
+	if err != nil {
+		return client, status.Error(codes.Unauthenticated, "Invalid client credentials")
+	}
+
+	return client, nil
+}
+
+// authenticateClient authenticates a confidential client from basic authorization
+func (uc OAuthUsecase) authenticateClient(ctx context.Context) (domain.Client, error) {
+	clientID, clientSecret, err := uc.jwt.GetBasicCredentials(ctx)
+	if err != nil {
+		return domain.Client{}, err
+	}
+
+	client, err := uc.verifyClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return client, err
+	}
+
+	if client.IsPublic {
+		return client, status.Error(codes.Unauthenticated, "Invalid client credentials")
+	}
+
+	return client, nil
+}
+
+func (uc OAuthUsecase) IntrospectToken(ctx context.Context, req domain.IntrospectTokenRequest) (domain.IntrospectTokenResponse, error) {
+	var res domain.IntrospectTokenResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	client, err := uc.authenticateClient(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	// Only resource servers registered for it may look into tokens
+	if !client.HasScopes([]string{domain.SCOPE_INTROSPECT}) {
+		return res, status.Error(codes.PermissionDenied, "Client is not allowed to introspect tokens")
+	}
+
+	if req.Token == "" {
+		return res, status.Error(codes.InvalidArgument, "Token is required")
+	}
+
+	// The hint only decides which token type is tried first (RFC 7662 section 2.1)
+	tokenTypes := []string{domain.ACCESS_TOKEN, domain.REFRESH_TOKEN}
+	if req.TokenTypeHint == domain.REFRESH_TOKEN {
+		tokenTypes = []string{domain.REFRESH_TOKEN, domain.ACCESS_TOKEN}
+	}
+
+	for _, tokenType := range tokenTypes {
+		var claims jwt.MapClaims
+
+		switch tokenType {
+		case domain.ACCESS_TOKEN:
+			claims, err = uc.jwt.VerifyAccessToken(req.Token)
+		case domain.REFRESH_TOKEN:
+			claims, err = uc.jwt.VerifyRefreshToken(req.Token)
+		}
+		if err != nil {
+			if code := status.Code(err); code == codes.Internal || code == codes.DeadlineExceeded {
+				return res, err
+			}
+
+			continue
+		}
+
+		sub, _ := claims["sub"].(string)
+		role, _ := claims["role"].(float64)
+		exp, _ := claims["exp"].(float64)
+		iat, _ := claims["iat"].(float64)
+		azp, _ := claims["azp"].(string)
+		scope, _ := claims["scope"].(string)
+
+		subType := jwtio.SUBJECT_TYPE_USER
+		if jwtio.IsService(claims) {
+			subType = jwtio.SUBJECT_TYPE_SERVICE
+		}
+
+		res = domain.IntrospectTokenResponse{
+			Active:    true,
+			Sub:       sub,
+			Role:      int8(role),
+			Exp:       int64(exp),
+			Iat:       int64(iat),
+			TokenType: tokenType,
+			ClientID:  azp,
+			Scope:     scope,
+			SubType:   subType,
+		}
+
+		return res, nil
+	}
+
+	// Invalid, expired and revoked tokens are all reported as inactive
+	return res, nil
+}
+
+func (uc OAuthUsecase) UserInfo(ctx context.Context) (domain.UserInfo, error) {
+	var res domain.UserInfo
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	user, err := uc.ur.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return res, status.Error(codes.NotFound, fmt.Sprintf("User with id %s not found", userID))
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	res = domain.UserInfo{
+		Sub:           user.ID.Hex(),
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+	}
+
+	return res, nil
+}
+
+// Authorize issues a short lived single use authorization code for the signed in
+// user, bound to the client, the redirect uri and the PKCE code challenge.
+func (uc OAuthUsecase) Authorize(ctx context.Context, req domain.AuthorizeRequest) (domain.AuthorizeResponse, error) {
+	var res domain.AuthorizeResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	client, ok, err := uc.getClient(ctx, req.ClientID)
+	if err != nil {
+		return res, err
+	}
+
+	if !ok {
+		return res, status.Error(codes.InvalidArgument, "Unknown client")
+	}
+
+	// Never redirect to an unregistered uri, the error goes back to the caller instead
+	if !client.HasRedirectURI(req.RedirectURI) {
+		return res, status.Error(codes.InvalidArgument, "Redirect uri is not registered for this client")
+	}
+
+	if req.ResponseType != domain.RESPONSE_TYPE_CODE || !client.HasGrantType(domain.GRANT_TYPE_AUTHORIZATION_CODE) {
+		return res, status.Error(codes.InvalidArgument, "Unsupported response type")
+	}
+
+	if !client.HasScopes(oauth.ParseScope(req.Scope)) {
+		return res, status.Error(codes.InvalidArgument, "Requested scope is not allowed for this client")
+	}
+
+	if req.CodeChallengeMethod != oauth.CODE_CHALLENGE_METHOD_S256 || !oauth.IsValidCodeChallenge(req.CodeChallenge) {
+		return res, status.Error(codes.InvalidArgument, "A S256 code challenge is required")
+	}
+
+	claims := ctx.Value("claims")
+	userID := claims.(jwt.MapClaims)["id"].(string)
+
+	code, err := oauth.GenerateToken()
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	value, err := json.Marshal(domain.AuthorizationCode{
+		ClientID:            client.ClientID,
+		RedirectURI:         req.RedirectURI,
+		UserID:              userID,
+		Scope:               req.Scope,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+	})
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.cr.Set(domain.CacheItem{
+		Key:   domain.AUTHORIZATION_CODE_KEY_PREFIX + oauth.HashToken(code),
+		Value: string(value),
+		Exp:   int32(time.Now().Add(time.Second * time.Duration(uc.cfg.OAuth.AuthorizationCodeExpirySecond)).Unix()),
+	})
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	redirectTo, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		return res, status.Error(codes.InvalidArgument, "Invalid redirect uri")
+	}
+
+	query := redirectTo.Query()
+	query.Set("code", code)
+	if req.State != "" {
+		query.Set("state", req.State)
+	}
+	redirectTo.RawQuery = query.Encode()
+
+	res = domain.AuthorizeResponse{
+		Code:       code,
+		State:      req.State,
+		RedirectTo: redirectTo.String(),
+	}
+
+	return res, nil
+}
+
+func (uc OAuthUsecase) Token(ctx context.Context, req domain.TokenRequest) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	switch req.GrantType {
+	case domain.GRANT_TYPE_AUTHORIZATION_CODE:
+		return uc.exchangeAuthorizationCode(ctx, req)
+	case domain.GRANT_TYPE_CLIENT_CREDENTIALS:
+		return uc.clientCredentials(ctx, req)
+	}
+
+	return res, status.Error(codes.InvalidArgument, "Unsupported grant type")
+}
+
+// authenticateTokenClient identifies the client calling the token endpoint, with
+// basic authorization or credentials in the body. Public clients only send their id.
+func (uc OAuthUsecase) authenticateTokenClient(ctx context.Context, req domain.TokenRequest) (domain.Client, error) {
+	clientID, clientSecret := req.ClientID, req.ClientSecret
+
+	basicID, basicSecret, err := uc.jwt.GetBasicCredentials(ctx)
+	if err == nil {
+		clientID, clientSecret = basicID, basicSecret
+	}
+
+	client, err := uc.verifyClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return client, err
+	}
+
+	if !client.HasGrantType(req.GrantType) {
+		return client, status.Error(codes.PermissionDenied, "Grant type is not allowed for this client")
+	}
+
+	return client, nil
+}
+
+func (uc OAuthUsecase) exchangeAuthorizationCode(ctx context.Context, req domain.TokenRequest) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+
+	client, err := uc.authenticateTokenClient(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	key := domain.AUTHORIZATION_CODE_KEY_PREFIX + oauth.HashToken(req.Code)
+
+	item, err := uc.cr.Get(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return res, status.Error(codes.InvalidArgument, "Invalid authorization code")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	// Whoever deletes the code first owns it, a replayed code finds nothing to delete
+	err = uc.cr.Delete(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrCacheMiss) {
+			return res, status.Error(codes.InvalidArgument, "Invalid authorization code")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	var authorizationCode domain.AuthorizationCode
+
+	err = json.Unmarshal([]byte(item.Value), &authorizationCode)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	if authorizationCode.ClientID != client.ClientID || authorizationCode.RedirectURI != req.RedirectURI {
+		return res, status.Error(codes.InvalidArgument, "Invalid authorization code")
+	}
+
+	if !oauth.VerifyCodeChallenge(req.CodeVerifier, authorizationCode.CodeChallenge, authorizationCode.CodeChallengeMethod) {
+		return res, status.Error(codes.InvalidArgument, "Invalid code verifier")
+	}
+
+	user, err := uc.ur.GetByID(ctx, authorizationCode.UserID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return res, status.Error(codes.InvalidArgument, "Invalid authorization code")
+		}
+
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	if !user.IsActive || user.DeletedAt != 0 {
+		return res, status.Error(codes.Unauthenticated, "Your account has been deleted")
+	}
+
+	res, err = issueTokens(ctx, uc.jwt, uc.cfg, uc.sr, tokenRequest{
+		user:   user,
+		client: client,
+		scope:  authorizationCode.Scope,
+		nonce:  authorizationCode.Nonce,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (uc OAuthUsecase) clientCredentials(ctx context.Context, req domain.TokenRequest) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+
+	client, err := uc.authenticateTokenClient(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	// A public client has no secret to prove it is the service it claims to be
+	if client.IsPublic {
+		return res, status.Error(codes.Unauthenticated, "Invalid client credentials")
+	}
+
+	// Without a scope parameter the client gets every scope it is allowed
+	scopes := oauth.ParseScope(req.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+
+	if !client.HasScopes(scopes) {
+		return res, status.Error(codes.InvalidArgument, "Requested scope is not allowed for this client")
+	}
+
+	res, err = issueServiceToken(uc.jwt, uc.cfg, client, strings.Join(scopes, " "))
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,377 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/oauth"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// withBasicAuth authenticates a request as the client
+func withBasicAuth(clientID, clientSecret string) context.Context {
+	credentials := base64.StdEncoding.EncodeToString([]byte(clientID + ":" + clientSecret))
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic "+credentials))
+}
+
+func TestIntrospectToken(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.oauthUsecase()
+	user := env.addUser(t, domain.CUSTOMER)
+
+	resourceServer, secret := env.addClient(t, domain.Client{
+		Name:       "Resource server",
+		GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+		Scopes:     []string{domain.SCOPE_INTROSPECT},
+	})
+
+	issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	ctx := withBasicAuth(resourceServer.ClientID, secret)
+
+	res, err := uc.IntrospectToken(ctx, domain.IntrospectTokenRequest{Token: issued.AccessToken})
+	require.NoError(t, err)
+	assert.True(t, res.Active)
+	assert.Equal(t, user.Email, res.Sub)
+	assert.Equal(t, user.Role, res.Role)
+	assert.Equal(t, domain.ACCESS_TOKEN, res.TokenType)
+	assert.Equal(t, jwtio.SUBJECT_TYPE_USER, res.SubType)
+	assert.Equal(t, int64(env.cfg.Jwt.AccessTokenExpiryHour*60*60), res.Exp-res.Iat)
+
+	res, err = uc.IntrospectToken(ctx, domain.IntrospectTokenRequest{Token: issued.RefreshToken, TokenTypeHint: domain.REFRESH_TOKEN})
+	require.NoError(t, err)
+	assert.True(t, res.Active)
+	assert.Equal(t, domain.REFRESH_TOKEN, res.TokenType)
+
+	// Garbage and revoked tokens are inactive, not errors
+	res, err = uc.IntrospectToken(ctx, domain.IntrospectTokenRequest{Token: "not-a-token"})
+	require.NoError(t, err)
+	assert.False(t, res.Active)
+
+	require.NoError(t, env.jwt.RevokeUserTokens(user.ID.Hex()))
+
+	res, err = uc.IntrospectToken(ctx, domain.IntrospectTokenRequest{Token: issued.AccessToken})
+	require.NoError(t, err)
+	assert.False(t, res.Active)
+}
+
+func TestIntrospectTokenRejectsClients(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.oauthUsecase()
+
+	resourceServer, secret := env.addClient(t, domain.Client{
+		Name:       "Resource server",
+		GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+		Scopes:     []string{domain.SCOPE_INTROSPECT},
+	})
+
+	other, otherSecret := env.addClient(t, domain.Client{
+		Name:       "Other service",
+		GrantTypes: []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+	})
+
+	spa, _ := env.addClient(t, domain.Client{
+		Name:         "Single page app",
+		IsPublic:     true,
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []string{domain.SCOPE_INTROSPECT},
+	})
+
+	req := domain.IntrospectTokenRequest{Token: "token"}
+
+	_, err := uc.IntrospectToken(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "no credentials")
+
+	_, err = uc.IntrospectToken(withBasicAuth(resourceServer.ClientID, "wrong"), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "wrong secret")
+
+	_, err = uc.IntrospectToken(withBasicAuth("unknown", secret), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "unknown client")
+
+	_, err = uc.IntrospectToken(withBasicAuth(spa.ClientID, ""), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "public client")
+
+	_, err = uc.IntrospectToken(withBasicAuth(other.ClientID, otherSecret), req)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "client without the introspect scope")
+}
+
+// RFC 7636 appendix B
+const (
+	codeVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	codeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+// signedIn returns the context of a request made by the user
+func signedIn(user domain.User) context.Context {
+	return context.WithValue(context.Background(), "claims", jwt.MapClaims{"id": user.ID.Hex()})
+}
+
+func authorizeRequest(client domain.Client) domain.AuthorizeRequest {
+	return domain.AuthorizeRequest{
+		ResponseType:        domain.RESPONSE_TYPE_CODE,
+		ClientID:            client.ClientID,
+		RedirectURI:         client.RedirectURIs[0],
+		Scope:               "openid",
+		State:               "state",
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: oauth.CODE_CHALLENGE_METHOD_S256,
+		Nonce:               "nonce",
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.oauthUsecase()
+	user := env.addUser(t, domain.CUSTOMER)
+
+	spa, _ := env.addClient(t, domain.Client{
+		Name:         "Single page app",
+		IsPublic:     true,
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []string{"openid"},
+	})
+
+	service, _ := env.addClient(t, domain.Client{
+		Name:         "Service",
+		GrantTypes:   []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+		RedirectURIs: []string{"https://service.example.com/callback"},
+	})
+
+	t.Run("success", func(t *testing.T) {
+		res, err := uc.Authorize(signedIn(user), authorizeRequest(spa))
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.Code)
+		assert.Equal(t, "state", res.State)
+		assert.Equal(t, "https://app.example.com/callback?code="+res.Code+"&state=state", res.RedirectTo)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tests := map[string]func(req *domain.AuthorizeRequest){
+			"unknown client":         func(req *domain.AuthorizeRequest) { req.ClientID = "unknown" },
+			"unregistered redirect":  func(req *domain.AuthorizeRequest) { req.RedirectURI = "https://evil.example.com/callback" },
+			"redirect prefix":        func(req *domain.AuthorizeRequest) { req.RedirectURI += "/more" },
+			"implicit flow":          func(req *domain.AuthorizeRequest) { req.ResponseType = "token" },
+			"scope not allowed":      func(req *domain.AuthorizeRequest) { req.Scope = "openid admin" },
+			"plain challenge":        func(req *domain.AuthorizeRequest) { req.CodeChallengeMethod = "plain" },
+			"missing challenge":      func(req *domain.AuthorizeRequest) { req.CodeChallenge = "" },
+			"malformed challenge":    func(req *domain.AuthorizeRequest) { req.CodeChallenge = "not a digest" },
+			"no authorization grant": func(req *domain.AuthorizeRequest) { *req = authorizeRequest(service) },
+		}
+
+		for name, modify := range tests {
+			req := authorizeRequest(spa)
+			modify(&req)
+
+			_, err := uc.Authorize(signedIn(user), req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+}
+
+func TestExchangeAuthorizationCode(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.oauthUsecase()
+	user := env.addUser(t, domain.CUSTOMER)
+
+	spa, _ := env.addClient(t, domain.Client{
+		Name:         "Single page app",
+		IsPublic:     true,
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Scopes:       []string{"openid"},
+	})
+
+	web, secret := env.addClient(t, domain.Client{
+		Name:         "Web app",
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://web.example.com/callback"},
+		Scopes:       []string{"openid"},
+	})
+
+	authorize := func(client domain.Client) string {
+		res, err := uc.Authorize(signedIn(user), authorizeRequest(client))
+		require.NoError(t, err)
+
+		return res.Code
+	}
+
+	tokenRequest := func(client domain.Client, code string) domain.TokenRequest {
+		return domain.TokenRequest{
+			GrantType:    domain.GRANT_TYPE_AUTHORIZATION_CODE,
+			Code:         code,
+			RedirectURI:  client.RedirectURIs[0],
+			ClientID:     client.ClientID,
+			CodeVerifier: codeVerifier,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		code := authorize(spa)
+
+		res, err := uc.Token(context.Background(), tokenRequest(spa, code))
+		require.NoError(t, err)
+		assert.NotEmpty(t, res.RefreshToken)
+		assert.NotEmpty(t, res.IDToken)
+		assert.Equal(t, "openid", res.Scope)
+
+		claims, err := env.jwt.VerifyAccessToken(res.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.Hex(), claims["id"])
+		assert.Equal(t, spa.ClientID, claims["azp"])
+
+		// A code is spent by its first exchange
+		_, err = uc.Token(context.Background(), tokenRequest(spa, code))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		// Confidential clients prove themselves with their secret
+		req := tokenRequest(web, authorize(web))
+		req.ClientSecret = secret
+
+		_, err = uc.Token(context.Background(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		tests := map[string]struct {
+			modify func(req *domain.TokenRequest)
+			code   codes.Code
+		}{
+			"unknown code":      {func(req *domain.TokenRequest) { req.Code = "unknown" }, codes.InvalidArgument},
+			"wrong verifier":    {func(req *domain.TokenRequest) { req.CodeVerifier = strings.Repeat("a", 43) }, codes.InvalidArgument},
+			"missing verifier":  {func(req *domain.TokenRequest) { req.CodeVerifier = "" }, codes.InvalidArgument},
+			"other redirect":    {func(req *domain.TokenRequest) { req.RedirectURI = "https://app.example.com/other" }, codes.InvalidArgument},
+			"other client":      {func(req *domain.TokenRequest) { req.ClientID, req.ClientSecret = web.ClientID, secret }, codes.InvalidArgument},
+			"unknown client":    {func(req *domain.TokenRequest) { req.ClientID = "unknown" }, codes.Unauthenticated},
+			"unsupported grant": {func(req *domain.TokenRequest) { req.GrantType = "password" }, codes.InvalidArgument},
+		}
+
+		for name, tt := range tests {
+			code := authorize(spa)
+
+			req := tokenRequest(spa, code)
+			tt.modify(&req)
+
+			_, err := uc.Token(context.Background(), req)
+			assert.Equal(t, tt.code, status.Code(err), name)
+		}
+
+		// A confidential client without its secret gets nothing
+		_, err := uc.Token(context.Background(), tokenRequest(web, authorize(web)))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "missing secret")
+
+		// A failed exchange still spends the code, a guessed verifier gets one try
+		code := authorize(spa)
+
+		req := tokenRequest(spa, code)
+		req.CodeVerifier = strings.Repeat("a", 43)
+
+		_, err = uc.Token(context.Background(), req)
+		require.Error(t, err)
+
+		_, err = uc.Token(context.Background(), tokenRequest(spa, code))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "code reused after a failed exchange")
+
+		// Users deactivated since they authorized are refused
+		code = authorize(spa)
+		require.NoError(t, env.ur.Update(context.Background(), domain.UpdateUser{ID: user.ID}))
+
+		_, err = uc.Token(context.Background(), tokenRequest(spa, code))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "deactivated user")
+	})
+}
+
+func TestClientCredentials(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.oauthUsecase()
+
+	service, secret := env.addClient(t, domain.Client{
+		Name:                  "Service",
+		GrantTypes:            []string{domain.GRANT_TYPE_CLIENT_CREDENTIALS},
+		Scopes:                []string{"orders.read", "orders.write"},
+		AccessTokenExpiryHour: 2,
+	})
+
+	web, webSecret := env.addClient(t, domain.Client{
+		Name:         "Web app",
+		GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		RedirectURIs: []string{"https://web.example.com/callback"},
+	})
+
+	req := domain.TokenRequest{GrantType: domain.GRANT_TYPE_CLIENT_CREDENTIALS}
+
+	t.Run("success", func(t *testing.T) {
+		// Without a scope the client gets every scope it is allowed
+		res, err := uc.Token(withBasicAuth(service.ClientID, secret), req)
+		require.NoError(t, err)
+		assert.Empty(t, res.RefreshToken)
+		assert.Empty(t, res.IDToken)
+		assert.Equal(t, "orders.read orders.write", res.Scope)
+		assert.Equal(t, int64(2*60*60), res.ExpiresIn)
+
+		claims, err := env.jwt.VerifyAccessToken(res.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, service.ClientID, claims["sub"])
+		assert.Equal(t, jwtio.SUBJECT_TYPE_SERVICE, claims["sub_type"])
+
+		// Credentials may come in the body too, and narrow the scope
+		res, err = uc.Token(context.Background(), domain.TokenRequest{
+			GrantType:    domain.GRANT_TYPE_CLIENT_CREDENTIALS,
+			ClientID:     service.ClientID,
+			ClientSecret: secret,
+			Scope:        "orders.read",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "orders.read", res.Scope)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := uc.Token(withBasicAuth(service.ClientID, "wrong"), req)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "wrong secret")
+
+		_, err = uc.Token(context.Background(), req)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "no credentials")
+
+		_, err = uc.Token(withBasicAuth(web.ClientID, webSecret), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "grant not allowed")
+
+		scoped := req
+		scoped.Scope = "orders.read admin"
+
+		_, err = uc.Token(withBasicAuth(service.ClientID, secret), scoped)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "scope not allowed")
+	})
+
+	t.Run("public client", func(t *testing.T) {
+		// Registration refuses the combination, a client edited into it is still refused
+		spa, _ := env.addClient(t, domain.Client{
+			Name:         "Single page app",
+			IsPublic:     true,
+			GrantTypes:   []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+			RedirectURIs: []string{"https://app.example.com/callback"},
+		})
+
+		require.NoError(t, env.clr.Update(context.Background(), domain.UpdateClient{
+			ClientID:   spa.ClientID,
+			GrantTypes: list(domain.GRANT_TYPE_AUTHORIZATION_CODE, domain.GRANT_TYPE_CLIENT_CREDENTIALS),
+		}))
+
+		_, err := uc.Token(context.Background(), domain.TokenRequest{
+			GrantType: domain.GRANT_TYPE_CLIENT_CREDENTIALS,
+			ClientID:  spa.ClientID,
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
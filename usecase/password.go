@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/rbac"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passwordError rejects a password with every description as a field error on field
+func passwordError(field string, descriptions ...string) error {
+	badRequest := &errdetails.BadRequest{}
+	for _, val := range descriptions {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: val,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "Password does not meet the password policy")
+
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// checkPassword rejects a password breaking the policy
+func checkPassword(policy *password.Policy, field, pw, name, email string) error {
+	violations := policy.Check(pw, name, email)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(violations))
+	for _, val := range violations {
+		descriptions = append(descriptions, val.Description)
+	}
+
+	return passwordError(field, descriptions...)
+}
+
+// passwordHistory keeps users from going back to one of their latest passwords.
+// The current password lives on the user, the ones before it in the history.
+type passwordHistory struct {
+	cfg    password.Config
+	phr    PasswordHistoryRepository
+	roles  *rbac.Resolver
+	hasher *password.Hasher
+}
+
+func (h passwordHistory) depth(ctx context.Context, role int8) (int, error) {
+	name, err := h.roles.RoleName(ctx, role)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	return h.cfg.HistoryDepthOf(name), nil
+}
+
+// check rejects pw when it matches the current password hash or one of the
+// hashes before it within the depth of the role
+func (h passwordHistory) check(ctx context.Context, field, userID string, role int8, current, pw string) error {
+	depth, err := h.depth(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	if depth <= 0 {
+		return nil
+	}
+
+	hashes := []string{current}
+
+	if depth > 1 {
+		history, err := h.phr.GetByUserID(ctx, userID)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		previous := history.Passwords
+		if len(previous) > depth-1 {
+			previous = previous[len(previous)-(depth-1):]
+		}
+
+		hashes = append(hashes, previous...)
+	}
+
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+
+		if valid, _ := h.hasher.Verify(hash, pw); valid {
+			return passwordError(field, fmt.Sprintf("Must not be one of your last %d passwords", depth))
+		}
+	}
+
+	return nil
+}
+
+// record moves the current password hash into the history, before the new
+// password replaces it
+func (h passwordHistory) record(ctx context.Context, userID string, role int8, current string) error {
+	depth, err := h.depth(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	keep := depth - 1
+	if keep <= 0 || current == "" {
+		return nil
+	}
+
+	err = h.phr.Push(ctx, userID, current, keep)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/digisata/auth-service/bootstrap"
 	"github.com/digisata/auth-service/domain"
 	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/rbac"
 	memcachedRepo "github.com/digisata/auth-service/repository/memcached"
 	mongoRepo "github.com/digisata/auth-service/repository/mongo"
 	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -22,19 +24,29 @@ type ProfileUsecase struct {
 	jwt     *jwtio.JSONWebToken
 	cfg     *bootstrap.Config
 	ur      ProfileRepository
+	sr      SessionRepository
 	cr      CacheRepository
+	roles   *rbac.Resolver
+	policy  *password.Policy
+	hasher  *password.Hasher
+	history passwordHistory
 	timeout time.Duration
 }
 
 var _ ProfileRepository = (*mongoRepo.ProfileRepository)(nil)
 var _ CacheRepository = (*memcachedRepo.CacheRepository)(nil)
 
-func NewProfileUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur ProfileRepository, cr CacheRepository, timeout time.Duration) *ProfileUsecase {
+func NewProfileUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur ProfileRepository, phr PasswordHistoryRepository, sr SessionRepository, cr CacheRepository, roles *rbac.Resolver, policy *password.Policy, hasher *password.Hasher, timeout time.Duration) *ProfileUsecase {
 	return &ProfileUsecase{
 		jwt:     jwt,
 		cfg:     cfg,
 		ur:      ur,
+		sr:      sr,
 		cr:      cr,
+		roles:   roles,
+		policy:  policy,
+		hasher:  hasher,
+		history: passwordHistory{cfg: cfg.Password, phr: phr, roles: roles, hasher: hasher},
 		timeout: timeout,
 	}
 }
@@ -72,23 +84,132 @@ func (uc ProfileUsecase) ChangePassword(ctx context.Context, req domain.ChangePa
 		return status.Error(codes.Internal, err.Error())
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword))
-	if err != nil {
+	valid, _ := uc.hasher.Verify(user.Password, req.OldPassword)
+	if !valid {
 		return status.Error(codes.InvalidArgument, "Incorrect password")
 	}
 
-	encryptedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(req.NewPassword),
-		bcrypt.DefaultCost,
-	)
+	err = checkPassword(uc.policy, "new_password", req.NewPassword, user.Name, user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = uc.history.check(ctx, "new_password", profileID, user.Role, user.Password, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	encryptedPassword, err := uc.hasher.Hash(req.NewPassword)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.history.record(ctx, profileID, user.Role, user.Password)
+	if err != nil {
+		return err
+	}
+
+	err = uc.ur.ChangePassword(ctx, profileID, encryptedPassword)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	// Whoever else knew the old password is signed out, and so is this session
+	err = revokeUserTokens(ctx, uc.jwt, uc.sr, profileID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListSessions returns where the signed in user is signed in, flagging the
+// session the request was made with
+func (uc ProfileUsecase) ListSessions(ctx context.Context) ([]domain.SessionResponse, error) {
+	var res []domain.SessionResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims").(jwt.MapClaims)
+	profileID := claims["id"].(string)
+	currentID, _ := claims["sid"].(string)
+
+	sessions, err := uc.sr.GetActiveByUserID(ctx, profileID)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	res = make([]domain.SessionResponse, 0, len(sessions))
+	for _, val := range sessions {
+		res = append(res, domain.SessionResponse{
+			Session: val,
+			Current: val.ID.Hex() == currentID,
+		})
+	}
+
+	return res, nil
+}
+
+// RevokeSession signs the user out of one of their sessions, sessions of other
+// users read as not found
+func (uc ProfileUsecase) RevokeSession(ctx context.Context, sessionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims").(jwt.MapClaims)
+	profileID := claims["id"].(string)
+
+	notFound := status.Error(codes.NotFound, fmt.Sprintf("Session with id %s not found", sessionID))
+
+	if !primitive.IsValidObjectID(sessionID) {
+		return notFound
+	}
+
+	session, err := uc.sr.GetByID(ctx, sessionID)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return notFound
+		}
+
 		return status.Error(codes.Internal, err.Error())
 	}
 
-	err = uc.ur.ChangePassword(ctx, profileID, string(encryptedPassword))
+	if session.UserID != profileID {
+		return notFound
+	}
+
+	if session.RevokedAt != 0 {
+		return nil
+	}
+
+	return revokeSession(ctx, uc.jwt, uc.cfg, uc.sr, sessionID, time.Unix(session.ExpiresAt, 0))
+}
+
+// RevokeOtherSessions signs the user out everywhere but the session the request
+// was made with
+func (uc ProfileUsecase) RevokeOtherSessions(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	claims := ctx.Value("claims").(jwt.MapClaims)
+	profileID := claims["id"].(string)
+	currentID, _ := claims["sid"].(string)
+
+	sessions, err := uc.sr.GetActiveByUserID(ctx, profileID)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
 
+	for _, val := range sessions {
+		if val.ID.Hex() == currentID {
+			continue
+		}
+
+		err = revokeSession(ctx, uc.jwt, uc.cfg, uc.sr, val.ID.Hex(), time.Unix(val.ExpiresAt, 0))
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
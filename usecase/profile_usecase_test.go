@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// signIn starts a session for the user and returns its tokens with the context
+// of a request made with them
+func (env *testEnv) signIn(t *testing.T, user domain.User) (domain.AuthResponse, context.Context) {
+	issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	claims, err := env.jwt.VerifyAccessToken(issued.AccessToken)
+	require.NoError(t, err)
+
+	return issued, context.WithValue(context.Background(), "claims", claims)
+}
+
+func TestListSessions(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.profileUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	_, ctx := env.signIn(t, user)
+	env.signIn(t, user)
+	env.signIn(t, env.addUser(t, domain.CUSTOMER))
+
+	sessions, err := uc.ListSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	current := 0
+	for _, val := range sessions {
+		assert.Equal(t, user.ID.Hex(), val.UserID)
+		if val.Current {
+			current++
+		}
+	}
+
+	assert.Equal(t, 1, current)
+}
+
+func TestRevokeSession(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.profileUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	current, ctx := env.signIn(t, user)
+	phone, phoneCtx := env.signIn(t, user)
+	_, otherCtx := env.signIn(t, env.addUser(t, domain.CUSTOMER))
+
+	sessionID := phoneCtx.Value("claims").(jwt.MapClaims)["sid"].(string)
+
+	t.Run("error", func(t *testing.T) {
+		// Sessions of other users are as good as missing
+		err := uc.RevokeSession(otherCtx, sessionID)
+		assert.Equal(t, codes.NotFound, status.Code(err), "other user")
+
+		err = uc.RevokeSession(ctx, primitive.NewObjectID().Hex())
+		assert.Equal(t, codes.NotFound, status.Code(err), "unknown session")
+
+		err = uc.RevokeSession(ctx, "not-an-id")
+		assert.Equal(t, codes.NotFound, status.Code(err), "invalid id")
+
+		_, err = env.jwt.VerifyAccessToken(phone.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, uc.RevokeSession(ctx, sessionID))
+
+		_, err := env.jwt.VerifyAccessToken(phone.AccessToken)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "access token")
+
+		_, err = env.jwt.VerifyRefreshToken(phone.RefreshToken)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), "refresh token")
+
+		_, err = env.jwt.VerifyAccessToken(current.AccessToken)
+		assert.NoError(t, err, "current session")
+
+		// Revoking twice is fine
+		assert.NoError(t, uc.RevokeSession(ctx, sessionID))
+
+		sessions, err := uc.ListSessions(ctx)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.True(t, sessions[0].Current)
+	})
+}
+
+func TestRevokeOtherSessions(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.profileUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	current, ctx := env.signIn(t, user)
+	laptop, _ := env.signIn(t, user)
+	phone, _ := env.signIn(t, user)
+	other, _ := env.signIn(t, env.addUser(t, domain.CUSTOMER))
+
+	require.NoError(t, uc.RevokeOtherSessions(ctx))
+
+	for name, issued := range map[string]domain.AuthResponse{"laptop": laptop, "phone": phone} {
+		_, err := env.jwt.VerifyAccessToken(issued.AccessToken)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+
+		_, err = env.jwt.VerifyRefreshToken(issued.RefreshToken)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+
+	for name, issued := range map[string]domain.AuthResponse{"current": current, "other user": other} {
+		_, err := env.jwt.VerifyAccessToken(issued.AccessToken)
+		assert.NoError(t, err, name)
+
+		_, err = env.jwt.VerifyRefreshToken(issued.RefreshToken)
+		assert.NoError(t, err, name)
+	}
+}
@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/rbac"
+	mongoRepo "github.com/digisata/auth-service/repository/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RoleUsecase struct {
+	cfg      *bootstrap.Config
+	rr       RoleRepository
+	pr       PermissionRepository
+	resolver *rbac.Resolver
+	timeout  time.Duration
+}
+
+var _ RoleRepository = (*mongoRepo.RoleRepository)(nil)
+var _ PermissionRepository = (*mongoRepo.PermissionRepository)(nil)
+
+func NewRoleUsecase(cfg *bootstrap.Config, rr RoleRepository, pr PermissionRepository, resolver *rbac.Resolver, timeout time.Duration) *RoleUsecase {
+	return &RoleUsecase{
+		cfg:      cfg,
+		rr:       rr,
+		pr:       pr,
+		resolver: resolver,
+		timeout:  timeout,
+	}
+}
+
+// NewRoleLoader reads the roles the resolver answers from out of the roles
+// collection
+func NewRoleLoader(rr RoleRepository) rbac.Loader {
+	return func(ctx context.Context) ([]rbac.Role, error) {
+		roles, err := rr.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := make([]rbac.Role, 0, len(roles))
+		for _, role := range roles {
+			res = append(res, rbac.Role{
+				Code:        role.Code,
+				Name:        role.Name,
+				Permissions: role.Permissions,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+// Seed registers the permissions of this service and, on a database without
+// roles, creates the built-in roles. Admins are granted every permission, the
+// other roles none.
+func (uc RoleUsecase) Seed(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	err := uc.rr.EnsureIndexes(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = uc.pr.EnsureIndexes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range domain.PERMISSIONS {
+		err := uc.pr.Upsert(ctx, permission)
+		if err != nil {
+			return err
+		}
+	}
+
+	roles, err := uc.rr.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(roles) > 0 {
+		return nil
+	}
+
+	for _, code := range []domain.UserRole{domain.ADMIN, domain.CUSTOMER, domain.COMMITTEE} {
+		role := domain.Role{
+			ID:          primitive.NewObjectID(),
+			Code:        int8(code),
+			Name:        domain.ROLE_NAMES[code],
+			Permissions: []string{},
+		}
+
+		if code == domain.ADMIN {
+			for _, permission := range domain.PERMISSIONS {
+				role.Permissions = append(role.Permissions, permission.Name)
+			}
+		}
+
+		err = uc.rr.Create(ctx, role)
+		if err != nil {
+			// Another instance is seeding at the same time
+			if mongo.IsDuplicateKeyError(err) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	uc.resolver.Invalidate()
+
+	return nil
+}
+
+func (uc RoleUsecase) checkPermission(ctx context.Context, name string) error {
+	_, err := uc.pr.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Unknown permission %s", name))
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
+
+func (uc RoleUsecase) checkNameAvailable(ctx context.Context, name string, id primitive.ObjectID) error {
+	role, err := uc.rr.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if role.ID != id {
+		return status.Error(codes.AlreadyExists, fmt.Sprintf("Role %s already exists", name))
+	}
+
+	return nil
+}
+
+// nextCode returns the code after the highest one taken
+func (uc RoleUsecase) nextCode(ctx context.Context) (int8, error) {
+	roles, err := uc.rr.GetAll(ctx)
+	if err != nil {
+		return 0, status.Error(codes.Internal, err.Error())
+	}
+
+	var code int8
+	for _, role := range roles {
+		if role.Code > code {
+			code = role.Code
+		}
+	}
+
+	if code == math.MaxInt8 {
+		return 0, status.Error(codes.ResourceExhausted, "No role code left")
+	}
+
+	return code + 1, nil
+}
+
+// Create adds a role under the next free code, which is what users of the role
+// carry in their role field and tokens
+func (uc RoleUsecase) Create(ctx context.Context, req domain.Role) (domain.Role, error) {
+	var res domain.Role
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return res, status.Error(codes.InvalidArgument, "Name is required")
+	}
+
+	err := uc.checkNameAvailable(ctx, req.Name, primitive.NilObjectID)
+	if err != nil {
+		return res, err
+	}
+
+	for _, permission := range req.Permissions {
+		err = uc.checkPermission(ctx, permission)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	role := req
+	role.ID = primitive.NewObjectID()
+
+	// Codes and names are unique, a duplicate key means a role created meanwhile
+	// took the name, which is final, or the code, which is retried with the next one
+	for attempt := 1; ; attempt++ {
+		role.Code, err = uc.nextCode(ctx)
+		if err != nil {
+			return res, err
+		}
+
+		err = uc.rr.Create(ctx, role)
+		if err == nil {
+			break
+		}
+
+		if !mongo.IsDuplicateKeyError(err) {
+			return res, status.Error(codes.Internal, err.Error())
+		}
+
+		err = uc.checkNameAvailable(ctx, role.Name, primitive.NilObjectID)
+		if err != nil {
+			return res, err
+		}
+
+		if attempt == domain.ROLE_CREATE_ATTEMPTS {
+			return res, status.Error(codes.Aborted, "Role code is taken, try again")
+		}
+	}
+
+	uc.resolver.Invalidate()
+
+	res, err = uc.getByID(ctx, role.ID.Hex())
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (uc RoleUsecase) GetAll(ctx context.Context) ([]domain.Role, error) {
+	var res []domain.Role
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	res, err := uc.rr.GetAll(ctx)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
+
+func (uc RoleUsecase) getByID(ctx context.Context, id string) (domain.Role, error) {
+	role, err := uc.rr.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return role, status.Error(codes.NotFound, fmt.Sprintf("Role with id %s not found", id))
+		}
+
+		return role, status.Error(codes.Internal, err.Error())
+	}
+
+	return role, nil
+}
+
+// Update edits a role. Portals admit roles by name, so renaming a role a portal
+// admits is refused until the portals config follows.
+func (uc RoleUsecase) Update(ctx context.Context, req domain.UpdateRole) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	role, err := uc.getByID(ctx, req.ID.Hex())
+	if err != nil {
+		return err
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name != "" && !strings.EqualFold(req.Name, role.Name) {
+		portals := uc.cfg.Portals.Admitting(role.Name)
+		if len(portals) > 0 {
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("Role %s is admitted to portals %s, rename it in the portals config first", role.Name, strings.Join(portals, ", ")))
+		}
+	}
+
+	if req.Name != "" {
+		err = uc.checkNameAvailable(ctx, req.Name, role.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = uc.rr.Update(ctx, req)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	uc.resolver.Invalidate()
+
+	return nil
+}
+
+func (uc RoleUsecase) GrantPermission(ctx context.Context, req domain.RolePermissionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	role, err := uc.getByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.checkPermission(ctx, req.Permission)
+	if err != nil {
+		return err
+	}
+
+	err = uc.rr.AddPermission(ctx, role.ID, req.Permission)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	uc.resolver.Invalidate()
+
+	return nil
+}
+
+// RevokePermission takes a permission from a role. Taking roles.manage from the
+// last role holding it is refused, nobody could grant it back.
+func (uc RoleUsecase) RevokePermission(ctx context.Context, req domain.RolePermissionRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	role, err := uc.getByID(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if req.Permission == constants.PERMISSION_ROLES_MANAGE {
+		roles, err := uc.rr.GetAll(ctx)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		managers := 0
+		for _, val := range roles {
+			if val.ID != role.ID && val.HasPermission(constants.PERMISSION_ROLES_MANAGE) {
+				managers++
+			}
+		}
+
+		if managers == 0 {
+			return status.Error(codes.FailedPrecondition, "The last role managing roles can not lose that permission")
+		}
+	}
+
+	err = uc.rr.RemovePermission(ctx, role.ID, req.Permission)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	uc.resolver.Invalidate()
+
+	return nil
+}
+
+func (uc RoleUsecase) ListPermissions(ctx context.Context) ([]domain.Permission, error) {
+	var res []domain.Permission
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	res, err := uc.pr.GetAll(ctx)
+	if err != nil {
+		return res, status.Error(codes.Internal, err.Error())
+	}
+
+	return res, nil
+}
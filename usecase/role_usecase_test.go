@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/portal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRoleCreate(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.roleUsecase()
+
+	role, err := uc.Create(context.Background(), domain.Role{Name: "auditor"})
+	require.NoError(t, err)
+	assert.Equal(t, int8(domain.COMMITTEE)+1, role.Code)
+
+	_, err = uc.Create(context.Background(), domain.Role{Name: "auditor"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = uc.Create(context.Background(), domain.Role{Name: "editor", Permissions: []string{"unknown"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRoleCreateRetriesTakenCode(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.roleUsecase()
+
+	// Another role takes the code right after it was picked, once
+	raced := false
+	env.rr.beforeCreate = func() {
+		if raced {
+			return
+		}
+
+		raced = true
+		require.NoError(t, env.rr.Create(context.Background(), domain.Role{
+			ID:   primitive.NewObjectID(),
+			Code: int8(domain.COMMITTEE) + 1,
+			Name: "editor",
+		}))
+	}
+
+	role, err := uc.Create(context.Background(), domain.Role{Name: "auditor"})
+	require.NoError(t, err)
+	assert.Equal(t, int8(domain.COMMITTEE)+2, role.Code)
+}
+
+func TestRoleCreateLosesNameRace(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.roleUsecase()
+
+	raced := false
+	env.rr.beforeCreate = func() {
+		if raced {
+			return
+		}
+
+		raced = true
+		require.NoError(t, env.rr.Create(context.Background(), domain.Role{
+			ID:   primitive.NewObjectID(),
+			Code: int8(domain.COMMITTEE) + 1,
+			Name: "auditor",
+		}))
+	}
+
+	_, err := uc.Create(context.Background(), domain.Role{Name: "auditor"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestRoleUpdateKeepsPortalRoleNames(t *testing.T) {
+	env := newTestEnv(t)
+	env.cfg.Portals = portal.Config{domain.PORTAL_ADMIN: {"admin"}}
+	uc := env.roleUsecase()
+
+	admin, err := env.rr.GetByName(context.Background(), "admin")
+	require.NoError(t, err)
+
+	err = uc.Update(context.Background(), domain.UpdateRole{ID: admin.ID, Name: "superuser"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Other fields and roles no portal admits can still change
+	err = uc.Update(context.Background(), domain.UpdateRole{ID: admin.ID, Name: "admin", Description: "Back office"})
+	require.NoError(t, err)
+
+	customer, err := env.rr.GetByName(context.Background(), "customer")
+	require.NoError(t, err)
+
+	err = uc.Update(context.Background(), domain.UpdateRole{ID: customer.ID, Name: "client"})
+	require.NoError(t, err)
+
+	role, err := env.rr.GetByID(context.Background(), customer.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, "client", role.Name)
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// saveSession starts a session for a sign in, or marks the session of a refresh
+// as used, and returns its id
+func saveSession(ctx context.Context, sr SessionRepository, req tokenRequest, expiresAt int64) (string, error) {
+	if req.sessionID != "" {
+		err := sr.Touch(ctx, req.sessionID, expiresAt)
+		if err != nil {
+			return "", status.Error(codes.Internal, err.Error())
+		}
+
+		return req.sessionID, nil
+	}
+
+	session := domain.Session{
+		ID:        primitive.NewObjectID(),
+		UserID:    req.user.ID.Hex(),
+		ClientID:  req.client.ClientID,
+		UserAgent: utils.UserAgent(ctx),
+		IP:        utils.ClientIP(ctx),
+		ExpiresAt: expiresAt,
+	}
+
+	err := sr.Create(ctx, session)
+	if err != nil {
+		return "", status.Error(codes.Internal, err.Error())
+	}
+
+	return session.ID.Hex(), nil
+}
+
+// revokeSession ends a session and retires its tokens until until, or for as
+// long as an access token issued now would live if that is later
+func revokeSession(ctx context.Context, j *jwtio.JSONWebToken, cfg *bootstrap.Config, sr SessionRepository, sessionID string, until time.Time) error {
+	err := sr.Revoke(ctx, sessionID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	accessExpiresAt := time.Now().Add(time.Hour * time.Duration(cfg.Jwt.AccessTokenExpiryHour))
+	if until.Before(accessExpiresAt) {
+		until = accessExpiresAt
+	}
+
+	return j.RevokeSession(sessionID, until)
+}
+
+// revokeUserTokens kills every token of a user at once and closes the sessions
+// they belonged to
+func revokeUserTokens(ctx context.Context, j *jwtio.JSONWebToken, sr SessionRepository, userID string) error {
+	err := j.RevokeUserTokens(userID)
+	if err != nil {
+		return err
+	}
+
+	err = sr.RevokeByUserID(ctx, userID)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/digisata/auth-service/bootstrap"
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/utils"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// tokenEpochSource keeps the token generations of users on their user document,
+// where no cache eviction can lose a revocation
+type tokenEpochSource struct {
+	ur UserRepository
+}
+
+var _ jwtio.EpochSource = (*tokenEpochSource)(nil)
+
+func NewTokenEpochSource(ur UserRepository) jwtio.EpochSource {
+	return &tokenEpochSource{
+		ur: ur,
+	}
+}
+
+func (s tokenEpochSource) TokenEpoch(ctx context.Context, userID string) (int64, error) {
+	epoch, err := s.ur.GetTokenEpoch(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, jwtio.ErrUserNotFound
+	}
+
+	return epoch, err
+}
+
+func (s tokenEpochSource) NextTokenEpoch(ctx context.Context, userID string) (int64, error) {
+	epoch, err := s.ur.IncrementTokenEpoch(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, jwtio.ErrUserNotFound
+	}
+
+	return epoch, err
+}
+
+// tokenRequest describes who tokens are issued for. The client is empty for
+// first party logins, which use the lifetimes and audience from config.
+type tokenRequest struct {
+	user   domain.User
+	client domain.Client
+	scope  string
+	nonce  string
+	family jwtio.TokenFamily
+	// audience overrides the aud of the tokens, e.g. with the portal signed in to
+	audience string
+	// sessionID is the session tokens are refreshed for, empty starts a new one
+	sessionID string
+}
+
+// issueTokens signs the access, refresh and ID token of a user, jwtio registers
+// the access and refresh token in its token store as it signs them. The tokens
+// belong to the session of the request, or to a new one.
+func issueTokens(ctx context.Context, jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, sr SessionRepository, req tokenRequest) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+	payload := jwtio.Payload{
+		ID:            req.user.ID.Hex(),
+		Name:          req.user.Name,
+		Email:         req.user.Email,
+		EmailVerified: req.user.EmailVerified,
+		Role:          req.user.Role,
+		Audience:      req.client.ClientID,
+		ClientID:      req.client.ClientID,
+	}
+
+	if req.audience != "" {
+		payload.Audience = req.audience
+	}
+
+	accessTokenExpiryHour := cfg.Jwt.AccessTokenExpiryHour
+	if req.client.AccessTokenExpiryHour > 0 {
+		accessTokenExpiryHour = req.client.AccessTokenExpiryHour
+	}
+
+	refreshTokenExpiryHour := cfg.Jwt.RefreshTokenExpiryHour
+	if req.client.RefreshTokenExpiryHour > 0 {
+		refreshTokenExpiryHour = req.client.RefreshTokenExpiryHour
+	}
+
+	now := time.Now()
+	sessionExpiresAt := now.Add(time.Hour * time.Duration(refreshTokenExpiryHour)).Unix()
+
+	sessionID, err := saveSession(ctx, sr, req, sessionExpiresAt)
+	if err != nil {
+		return res, err
+	}
+
+	payload.SessionID = sessionID
+
+	payload.Epoch, err = jwt.TokenEpoch(payload.ID)
+	if err != nil {
+		return res, err
+	}
+
+	accessToken, err := jwt.CreateAccessToken(payload, now, accessTokenExpiryHour)
+	if err != nil {
+		return res, err
+	}
+
+	refreshToken, err := jwt.CreateRefreshToken(payload, req.family, now, refreshTokenExpiryHour)
+	if err != nil {
+		return res, err
+	}
+
+	idToken, err := jwt.CreateIDToken(payload, req.nonce, now, accessTokenExpiryHour)
+	if err != nil {
+		return res, err
+	}
+
+	res = domain.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		IDToken:      idToken,
+		ExpiresIn:    int64(time.Hour.Seconds()) * int64(accessTokenExpiryHour),
+		Scope:        req.scope,
+	}
+
+	return res, nil
+}
+
+// issueServiceToken signs an access token for a client acting on its own behalf.
+// Services get no refresh token, they authenticate again when the token expires.
+func issueServiceToken(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, client domain.Client, scope string) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+	payload := jwtio.ServicePayload{
+		ClientID: client.ClientID,
+		Scope:    scope,
+	}
+
+	accessTokenExpiryHour := cfg.Jwt.AccessTokenExpiryHour
+	if client.AccessTokenExpiryHour > 0 {
+		accessTokenExpiryHour = client.AccessTokenExpiryHour
+	}
+
+	now := time.Now()
+
+	accessToken, err := jwt.CreateServiceAccessToken(payload, now, accessTokenExpiryHour)
+	if err != nil {
+		return res, err
+	}
+
+	res = domain.AuthResponse{
+		AccessToken: accessToken,
+		ExpiresIn:   int64(time.Hour.Seconds()) * int64(accessTokenExpiryHour),
+		Scope:       scope,
+	}
+
+	return res, nil
+}
+
+// rotateRefreshToken uses up a refresh token and returns its claims with the
+// family the replacement joins. A token that was already rotated being presented
+// again means it leaked, so the whole family is revoked and the reuse recorded
+// (OAuth 2.0 Security BCP, refresh token rotation).
+func rotateRefreshToken(ctx context.Context, j *jwtio.JSONWebToken, cfg *bootstrap.Config, sr SessionRepository, ser SecurityEventRepository, refreshToken string) (jwt.MapClaims, jwtio.TokenFamily, error) {
+	claims, err := j.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, jwtio.TokenFamily{}, err
+	}
+
+	err = checkSessionActive(ctx, sr, claims)
+	if err != nil {
+		return nil, jwtio.TokenFamily{}, err
+	}
+
+	family, err := j.ConsumeRefreshToken(claims)
+	if err != nil {
+		if !errors.Is(err, jwtio.ErrTokenReused) {
+			return nil, family, err
+		}
+
+		err = revokeTokenFamily(ctx, j, cfg, sr, ser, claims)
+		if err != nil {
+			return nil, family, err
+		}
+
+		return nil, family, status.Error(codes.Unauthenticated, constants.REFRESH_TOKEN_REUSED)
+	}
+
+	return claims, family, nil
+}
+
+// checkSessionActive refuses refresh tokens of a revoked session. The cache mark
+// checked while parsing may have been evicted, the session document can't be.
+func checkSessionActive(ctx context.Context, sr SessionRepository, claims jwt.MapClaims) error {
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		return nil
+	}
+
+	session, err := sr.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.Unauthenticated, constants.REFRESH_TOKEN_EXPIRED)
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if session.RevokedAt != 0 {
+		return status.Error(codes.Unauthenticated, constants.REFRESH_TOKEN_EXPIRED)
+	}
+
+	return nil
+}
+
+// revokeTokenFamily revokes the family of a reused refresh token for as long as
+// a token of the family may live: every token of it was issued by now with the
+// same lifetime as the reused one. The session of the family ends with it.
+func revokeTokenFamily(ctx context.Context, j *jwtio.JSONWebToken, cfg *bootstrap.Config, sr SessionRepository, ser SecurityEventRepository, claims jwt.MapClaims) error {
+	familyID, _ := claims["fid"].(string)
+	jti, _ := claims["jti"].(string)
+	userID, _ := claims["id"].(string)
+	clientID, _ := claims["azp"].(string)
+	iat, _ := claims["iat"].(float64)
+	exp, _ := claims["exp"].(float64)
+
+	now := time.Now()
+	lifetime := time.Duration(exp-iat) * time.Second
+
+	err := j.RevokeTokenFamily(familyID, now.Add(lifetime))
+	if err != nil {
+		return err
+	}
+
+	sessionID, _ := claims["sid"].(string)
+	if sessionID != "" {
+		err = revokeSession(ctx, j, cfg, sr, sessionID, now.Add(lifetime))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = ser.Create(ctx, domain.SecurityEvent{
+		Type:     domain.SECURITY_EVENT_REFRESH_TOKEN_REUSE,
+		UserID:   userID,
+		ClientID: clientID,
+		FamilyID: familyID,
+		TokenID:  jti,
+		IP:       utils.ClientIP(ctx),
+	})
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return nil
+}
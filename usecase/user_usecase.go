@@ -6,14 +6,20 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/bradfitz/gomemcache/memcache"
 	"github.com/digisata/auth-service/bootstrap"
 	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/constants"
 	"github.com/digisata/auth-service/pkg/jwtio"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/rbac"
+	"github.com/digisata/auth-service/pkg/utils"
 	memcachedRepo "github.com/digisata/auth-service/repository/memcached"
+	memoryRepo "github.com/digisata/auth-service/repository/memory"
 	mongoRepo "github.com/digisata/auth-service/repository/mongo"
+	redisRepo "github.com/digisata/auth-service/repository/redis"
+	"github.com/golang-jwt/jwt/v4"
 	"go.mongodb.org/mongo-driver/mongo"
-	"golang.org/x/crypto/bcrypt"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -22,84 +28,94 @@ type UserUsecase struct {
 	jwt     *jwtio.JSONWebToken
 	cfg     *bootstrap.Config
 	ur      UserRepository
+	sr      SessionRepository
+	ser     SecurityEventRepository
+	clr     ClientRepository
 	cr      CacheRepository
+	roles   *rbac.Resolver
+	guard   loginGuard
+	policy  *password.Policy
+	hasher  *password.Hasher
+	history passwordHistory
+	logger  *zap.SugaredLogger
 	timeout time.Duration
 }
 
 var _ UserRepository = (*mongoRepo.UserRepository)(nil)
 var _ CacheRepository = (*memcachedRepo.CacheRepository)(nil)
+var _ CacheRepository = (*redisRepo.CacheRepository)(nil)
+var _ CacheRepository = (*memoryRepo.CacheRepository)(nil)
 
-func NewUserUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur UserRepository, cr CacheRepository, timeout time.Duration) *UserUsecase {
+func NewUserUsecase(jwt *jwtio.JSONWebToken, cfg *bootstrap.Config, ur UserRepository, phr PasswordHistoryRepository, sr SessionRepository, ser SecurityEventRepository, clr ClientRepository, cr CacheRepository, roles *rbac.Resolver, policy *password.Policy, hasher *password.Hasher, logger *zap.SugaredLogger, timeout time.Duration) *UserUsecase {
 	return &UserUsecase{
 		jwt:     jwt,
 		cfg:     cfg,
 		ur:      ur,
+		sr:      sr,
+		ser:     ser,
+		clr:     clr,
 		cr:      cr,
+		roles:   roles,
+		guard:   loginGuard{cfg: cfg.Lockout, cr: cr},
+		policy:  policy,
+		hasher:  hasher,
+		history: passwordHistory{cfg: cfg.Password, phr: phr, roles: roles, hasher: hasher},
+		logger:  logger,
 		timeout: timeout,
 	}
 }
 
-func (uc UserUsecase) generateToken(user domain.User) (domain.AuthResponse, error) {
-	var res domain.AuthResponse
-	payload := jwtio.Payload{
-		ID:    user.ID.Hex(),
-		Name:  user.Name,
-		Email: user.Email,
-		Role:  user.Role,
-	}
+func (uc UserUsecase) generateToken(ctx context.Context, user domain.User, audience string) (domain.AuthResponse, error) {
+	return issueTokens(ctx, uc.jwt, uc.cfg, uc.sr, tokenRequest{user: user, audience: audience})
+}
 
-	now := time.Now()
+// Login checks the password of a user signing in to a portal. Only users whose
+// role name is configured for the portal get in, and their tokens are issued for
+// the portal as audience. Every failure counts towards the lockout and reads the same,
+// whether the email, the role or the password was wrong.
+func (uc UserUsecase) Login(ctx context.Context, req domain.LoginRequest) (domain.AuthResponse, error) {
+	var res domain.AuthResponse
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
 
-	accessToken, err := uc.jwt.CreateAccessToken(payload, uc.cfg.Jwt.AccessTokenSecret, now, uc.cfg.Jwt.AccessTokenExpiryHour)
-	if err != nil {
-		return res, err
+	portal, ok := uc.cfg.Portals.Lookup(req.Portal)
+	if !ok {
+		return res, status.Error(codes.InvalidArgument, fmt.Sprintf("Unknown portal %s", req.Portal))
 	}
 
-	err = uc.cr.Set(domain.CacheItem{
-		Key: accessToken,
-		Exp: int32(now.Add(time.Hour * time.Duration(uc.cfg.Jwt.AccessTokenExpiryHour)).Unix()),
-	})
-	if err != nil {
-		return res, status.Error(codes.Internal, err.Error())
-	}
+	ip := utils.ClientIP(ctx)
 
-	refreshToken, err := uc.jwt.CreateRefreshToken(payload, uc.cfg.Jwt.RefreshTokenSecret, now, uc.cfg.Jwt.RefreshTokenExpiryHour)
+	err := uc.guard.check(req.Email, ip)
 	if err != nil {
 		return res, err
 	}
 
-	err = uc.cr.Set(domain.CacheItem{
-		Key: refreshToken,
-		Exp: int32(now.Add(time.Hour * time.Duration(uc.cfg.Jwt.RefreshTokenExpiryHour)).Unix()),
-	})
-	if err != nil {
-		return res, status.Error(codes.Internal, err.Error())
-	}
-
-	res = domain.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	}
-
-	return res, nil
-}
-
-func (uc UserUsecase) LoginAdmin(ctx context.Context, req domain.User) (domain.AuthResponse, error) {
-	var res domain.AuthResponse
-	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
-	defer cancel()
+	valid := false
 
 	user, err := uc.ur.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
+		// Hash anyway, so an unknown email takes as long as a wrong password
+		uc.hasher.VerifyDummy(req.Password)
+	} else {
+		valid, _ = uc.hasher.Verify(user.Password, req.Password)
 	}
 
-	if user.Role != int8(domain.ADMIN) {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
+	allowed := false
+	if valid {
+		roleName, err := uc.roles.RoleName(ctx, user.Role)
+		if err != nil {
+			return res, status.Error(codes.Internal, err.Error())
+		}
+
+		allowed = portal.Allows(roleName)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
-	if err != nil {
+	if !valid || !allowed {
+		err = uc.guard.fail(req.Email, ip)
+		if err != nil {
+			return res, err
+		}
+
 		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
 	}
 
@@ -107,38 +123,20 @@ func (uc UserUsecase) LoginAdmin(ctx context.Context, req domain.User) (domain.A
 		return res, status.Error(codes.Unauthenticated, "Your account has been deleted")
 	}
 
-	res, err = uc.generateToken(user)
-	if err != nil {
-		return res, err
-	}
-
-	return res, nil
-}
-
-func (uc UserUsecase) LoginCustomer(ctx context.Context, req domain.User) (domain.AuthResponse, error) {
-	var res domain.AuthResponse
-	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
-	defer cancel()
-
-	user, err := uc.ur.GetByEmail(ctx, req.Email)
-	if err != nil {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
-	}
+	uc.rehash(ctx, user, req.Password)
 
-	if user.Role != int8(domain.CUSTOMER) {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
+	// The password alone does not forgive earlier failures when a second factor
+	// is due, VerifyMFA does once it passes
+	if user.MFAEnabled {
+		return createMFAChallenge(uc.cfg, uc.cr, user, portal.Name)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = uc.guard.succeed(req.Email, ip)
 	if err != nil {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
-	}
-
-	if !user.IsActive || user.DeletedAt != 0 {
-		return res, status.Error(codes.Unauthenticated, "Your account has been deleted")
+		return res, err
 	}
 
-	res, err = uc.generateToken(user)
+	res, err = uc.generateToken(ctx, user, portal.Name)
 	if err != nil {
 		return res, err
 	}
@@ -146,35 +144,54 @@ func (uc UserUsecase) LoginCustomer(ctx context.Context, req domain.User) (domai
 	return res, nil
 }
 
-func (uc UserUsecase) LoginCommittee(ctx context.Context, req domain.User) (domain.AuthResponse, error) {
-	var res domain.AuthResponse
-	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
-	defer cancel()
-
-	user, err := uc.ur.GetByEmail(ctx, req.Email)
-	if err != nil {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
+// rehash moves the hash of a user to the configured algorithm and parameters
+// while the plain password is at hand. It is best effort, a failed rehash is
+// tried again on the next login.
+func (uc UserUsecase) rehash(ctx context.Context, user domain.User, password string) {
+	if !uc.hasher.NeedsRehash(user.Password) {
+		return
 	}
 
-	if user.Role != int8(domain.COMMITTEE) {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
+	hash, err := uc.hasher.Hash(password)
+	if err != nil {
+		uc.logger.Errorw(constants.ERROR,
+			"message", "failed to rehash password",
+			"user_id", user.ID.Hex(),
+			"error", err.Error(),
+		)
+		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = uc.ur.UpdatePassword(ctx, user.ID.Hex(), hash)
 	if err != nil {
-		return res, status.Error(codes.InvalidArgument, "Incorrect email or password")
+		uc.logger.Errorw(constants.ERROR,
+			"message", "failed to store rehashed password",
+			"user_id", user.ID.Hex(),
+			"error", err.Error(),
+		)
 	}
+}
 
-	if !user.IsActive || user.DeletedAt != 0 {
-		return res, status.Error(codes.Unauthenticated, "Your account has been deleted")
-	}
+// loginAs signs in to a fixed portal, which is what the per role login RPCs
+// predating Login do
+func (uc UserUsecase) loginAs(ctx context.Context, req domain.User, portal string) (domain.AuthResponse, error) {
+	return uc.Login(ctx, domain.LoginRequest{
+		Portal:   portal,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+}
 
-	res, err = uc.generateToken(user)
-	if err != nil {
-		return res, err
-	}
+func (uc UserUsecase) LoginAdmin(ctx context.Context, req domain.User) (domain.AuthResponse, error) {
+	return uc.loginAs(ctx, req, domain.PORTAL_ADMIN)
+}
 
-	return res, nil
+func (uc UserUsecase) LoginCustomer(ctx context.Context, req domain.User) (domain.AuthResponse, error) {
+	return uc.loginAs(ctx, req, domain.PORTAL_CUSTOMER)
+}
+
+func (uc UserUsecase) LoginCommittee(ctx context.Context, req domain.User) (domain.AuthResponse, error) {
+	return uc.loginAs(ctx, req, domain.PORTAL_COMMITTEE)
 }
 
 func (uc UserUsecase) RefreshToken(ctx context.Context, req domain.RefreshTokenRequest) (domain.AuthResponse, error) {
@@ -182,7 +199,7 @@ func (uc UserUsecase) RefreshToken(ctx context.Context, req domain.RefreshTokenR
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	claims, err := uc.jwt.VerifyRefreshToken(req.RefreshToken, uc.cfg.Jwt.RefreshTokenSecret)
+	claims, family, err := rotateRefreshToken(ctx, uc.jwt, uc.cfg, uc.sr, uc.ser, req.RefreshToken)
 	if err != nil {
 		return res, err
 	}
@@ -196,57 +213,56 @@ func (uc UserUsecase) RefreshToken(ctx context.Context, req domain.RefreshTokenR
 		return res, status.Error(codes.Internal, err.Error())
 	}
 
-	payload := jwtio.Payload{
-		ID:    user.ID.Hex(),
-		Name:  user.Name,
-		Email: user.Email,
-		Role:  user.Role,
-	}
+	// Tokens of a client keep its lifetimes and azp, a client removed since can
+	// no longer refresh them
+	var client domain.Client
 
-	now := time.Now()
+	clientID, _ := claims["azp"].(string)
+	if clientID != "" {
+		client, err = uc.clr.GetByClientID(ctx, clientID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return res, status.Error(codes.Unauthenticated, fmt.Sprintf("Client %s no longer exists", clientID))
+			}
 
-	newAccessToken, err := uc.jwt.CreateAccessToken(payload, uc.cfg.Jwt.AccessTokenSecret, now, uc.cfg.Jwt.AccessTokenExpiryHour)
-	if err != nil {
-		return res, err
+			return res, status.Error(codes.Internal, err.Error())
+		}
 	}
 
-	err = uc.cr.Set(domain.CacheItem{
-		Key: newAccessToken,
-		Exp: int32(now.Add(time.Hour * time.Duration(uc.cfg.Jwt.AccessTokenExpiryHour)).Unix()),
-	})
-	if err != nil {
-		return res, status.Error(codes.Internal, err.Error())
-	}
+	sessionID, _ := claims["sid"].(string)
 
-	newRefreshToken, err := uc.jwt.CreateRefreshToken(payload, uc.cfg.Jwt.RefreshTokenSecret, now, uc.cfg.Jwt.RefreshTokenExpiryHour)
+	// The new tokens are for the portal the user signed in to
+	res, err = issueTokens(ctx, uc.jwt, uc.cfg, uc.sr, tokenRequest{
+		user:      user,
+		client:    client,
+		family:    family,
+		audience:  jwtio.Audience(claims),
+		sessionID: sessionID,
+	})
 	if err != nil {
 		return res, err
 	}
 
-	err = uc.cr.Set(domain.CacheItem{
-		Key: newRefreshToken,
-		Exp: int32(now.Add(time.Hour * time.Duration(uc.cfg.Jwt.RefreshTokenExpiryHour)).Unix()),
-	})
+	err = uc.jwt.RetireAccessToken(req.AccessToken)
 	if err != nil {
-		return res, status.Error(codes.Internal, err.Error())
+		return res, err
 	}
 
-	err = uc.cr.Delete(req.AccessToken)
-	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
-		return res, status.Error(codes.Internal, err.Error())
-	}
+	return res, nil
+}
 
-	err = uc.cr.Delete(req.RefreshToken)
-	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
-		return res, status.Error(codes.Internal, err.Error())
+// checkRole refuses role codes no role is stored under
+func (uc UserUsecase) checkRole(ctx context.Context, code int8) error {
+	name, err := uc.roles.RoleName(ctx, code)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
 	}
 
-	res = domain.AuthResponse{
-		AccessToken:  newAccessToken,
-		RefreshToken: newRefreshToken,
+	if name == "" {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Unknown role %d", code))
 	}
 
-	return res, nil
+	return nil
 }
 
 func (uc UserUsecase) Create(ctx context.Context, req domain.User) error {
@@ -258,15 +274,22 @@ func (uc UserUsecase) Create(ctx context.Context, req domain.User) error {
 		return status.Error(codes.InvalidArgument, "User already exists with the given email")
 	}
 
-	encryptedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(req.Password),
-		bcrypt.DefaultCost,
-	)
+	err = uc.checkRole(ctx, req.Role)
+	if err != nil {
+		return err
+	}
+
+	err = checkPassword(uc.policy, "password", req.Password, req.Name, req.Email)
+	if err != nil {
+		return err
+	}
+
+	encryptedPassword, err := uc.hasher.Hash(req.Password)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
 
-	req.Password = string(encryptedPassword)
+	req.Password = encryptedPassword
 	err = uc.ur.Create(ctx, req)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
@@ -311,7 +334,7 @@ func (uc UserUsecase) Update(ctx context.Context, req domain.UpdateUser) error {
 
 	userID := req.ID.Hex()
 
-	_, err := uc.ur.GetByID(ctx, userID)
+	user, err := uc.ur.GetByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
 			return status.Error(codes.NotFound, fmt.Sprintf("User with id %s not found", userID))
@@ -320,11 +343,27 @@ func (uc UserUsecase) Update(ctx context.Context, req domain.UpdateUser) error {
 		return status.Error(codes.Internal, err.Error())
 	}
 
+	if req.Role != 0 {
+		err = uc.checkRole(ctx, req.Role)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = uc.ur.Update(ctx, req)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
 
+	// A deactivated user keeps no working tokens, nor does a user whose tokens
+	// carry a role they no longer have
+	if !req.IsActive || (req.Role != 0 && req.Role != user.Role) {
+		err = revokeUserTokens(ctx, uc.jwt, uc.sr, userID)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -348,6 +387,59 @@ func (uc UserUsecase) Delete(ctx context.Context, req domain.DeleteUser) error {
 		return status.Error(codes.Internal, err.Error())
 	}
 
+	err = revokeUserTokens(ctx, uc.jwt, uc.sr, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ChangePassword sets the password of a user on behalf of an admin and signs the
+// user out everywhere
+func (uc UserUsecase) ChangePassword(ctx context.Context, req domain.ChangeUserPasswordRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	user, err := uc.ur.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.NotFound, fmt.Sprintf("User with id %s not found", req.ID))
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = checkPassword(uc.policy, "new_password", req.NewPassword, user.Name, user.Email)
+	if err != nil {
+		return err
+	}
+
+	err = uc.history.check(ctx, "new_password", req.ID, user.Role, user.Password, req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	encryptedPassword, err := uc.hasher.Hash(req.NewPassword)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = uc.history.record(ctx, req.ID, user.Role, user.Password)
+	if err != nil {
+		return err
+	}
+
+	err = uc.ur.UpdatePassword(ctx, req.ID, encryptedPassword)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = revokeUserTokens(ctx, uc.jwt, uc.sr, req.ID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -357,15 +449,47 @@ func (uc UserUsecase) Logout(ctx context.Context, refreshToken string) error {
 
 	accessToken, _ := uc.jwt.GetAccessToken(ctx)
 
-	err := uc.cr.Delete(accessToken)
-	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+	err := uc.jwt.RetireAccessToken(accessToken)
+	if err != nil {
+		return err
+	}
+
+	err = uc.jwt.RetireRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	claims := ctx.Value("claims").(jwt.MapClaims)
+	sessionID, _ := claims["sid"].(string)
+	if sessionID == "" {
+		return nil
+	}
+
+	session, err := uc.sr.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+
 		return status.Error(codes.Internal, err.Error())
 	}
 
-	err = uc.cr.Delete(refreshToken)
-	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+	return revokeSession(ctx, uc.jwt, uc.cfg, uc.sr, sessionID, time.Unix(session.ExpiresAt, 0))
+}
+
+// Unlock lifts the lockout of a user, per IP locks included
+func (uc UserUsecase) Unlock(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	user, err := uc.ur.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return status.Error(codes.NotFound, fmt.Sprintf("User with id %s not found", userID))
+		}
+
 		return status.Error(codes.Internal, err.Error())
 	}
 
-	return nil
+	return uc.guard.unlock(user.Email)
 }
@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/digisata/auth-service/domain"
+	"github.com/digisata/auth-service/pkg/constants"
+	"github.com/digisata/auth-service/pkg/password"
+	"github.com/digisata/auth-service/pkg/portal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUserCreateChecksRole(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+
+	newUser := func(role int8) domain.User {
+		return domain.User{
+			ID:       primitive.NewObjectID(),
+			Name:     "Test",
+			Email:    primitive.NewObjectID().Hex() + "@example.com",
+			Password: "correct-horse-battery",
+			Role:     role,
+		}
+	}
+
+	err := uc.Create(context.Background(), newUser(int8(domain.CUSTOMER)))
+	assert.NoError(t, err)
+
+	err = uc.Create(context.Background(), newUser(42))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = uc.Create(context.Background(), newUser(0))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUserUpdateChecksRole(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	err := uc.Update(context.Background(), domain.UpdateUser{ID: user.ID, Role: 42, IsActive: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = uc.Update(context.Background(), domain.UpdateUser{ID: user.ID, Role: int8(domain.COMMITTEE), IsActive: true})
+	require.NoError(t, err)
+
+	user, err = env.ur.GetByID(context.Background(), user.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, int8(domain.COMMITTEE), user.Role)
+	assert.Equal(t, int64(1), user.TokenEpoch, "tokens carrying the old role are revoked")
+}
+
+func TestRefreshTokenKeepsClient(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	client := domain.Client{
+		ClientID:               "client-1",
+		GrantTypes:             []string{domain.GRANT_TYPE_AUTHORIZATION_CODE},
+		AccessTokenExpiryHour:  2,
+		RefreshTokenExpiryHour: 48,
+	}
+	require.NoError(t, env.clr.Create(context.Background(), client))
+
+	issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user, client: client})
+	require.NoError(t, err)
+
+	refreshed, err := uc.RefreshToken(context.Background(), domain.RefreshTokenRequest{
+		AccessToken:  issued.AccessToken,
+		RefreshToken: issued.RefreshToken,
+	})
+	require.NoError(t, err)
+
+	claims, err := env.jwt.VerifyAccessToken(refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, client.ClientID, claims["azp"])
+	assert.Equal(t, float64(2*60*60), claims["exp"].(float64)-claims["iat"].(float64))
+
+	refreshClaims, err := env.jwt.VerifyRefreshToken(refreshed.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, client.ClientID, refreshClaims["azp"])
+	assert.Equal(t, float64(48*60*60), refreshClaims["exp"].(float64)-refreshClaims["iat"].(float64))
+
+	// A removed client can not refresh its tokens
+	require.NoError(t, env.clr.Delete(context.Background(), client.ClientID))
+
+	_, err = uc.RefreshToken(context.Background(), domain.RefreshTokenRequest{
+		AccessToken:  refreshed.AccessToken,
+		RefreshToken: refreshed.RefreshToken,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestUserChangesRevokeTokens(t *testing.T) {
+	tests := map[string]func(uc *UserUsecase, user domain.User) error{
+		"password change": func(uc *UserUsecase, user domain.User) error {
+			return uc.ChangePassword(context.Background(), domain.ChangeUserPasswordRequest{ID: user.ID.Hex(), NewPassword: "correct-horse-battery"})
+		},
+		"deactivation": func(uc *UserUsecase, user domain.User) error {
+			return uc.Update(context.Background(), domain.UpdateUser{ID: user.ID, Name: user.Name})
+		},
+		"role change": func(uc *UserUsecase, user domain.User) error {
+			return uc.Update(context.Background(), domain.UpdateUser{ID: user.ID, Role: int8(domain.COMMITTEE), IsActive: true})
+		},
+		"deletion": func(uc *UserUsecase, user domain.User) error {
+			return uc.Delete(context.Background(), domain.DeleteUser{ID: user.ID})
+		},
+	}
+
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := newTestEnv(t)
+			uc := env.userUsecase(t)
+			user := env.addUser(t, domain.CUSTOMER)
+			other := env.addUser(t, domain.CUSTOMER)
+
+			issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+			require.NoError(t, err)
+
+			untouched, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: other})
+			require.NoError(t, err)
+
+			require.NoError(t, change(uc, user))
+
+			_, err = env.jwt.VerifyAccessToken(issued.AccessToken)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err), "access token")
+
+			_, err = env.jwt.VerifyRefreshToken(issued.RefreshToken)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err), "refresh token")
+
+			_, err = uc.RefreshToken(context.Background(), domain.RefreshTokenRequest{
+				AccessToken:  issued.AccessToken,
+				RefreshToken: issued.RefreshToken,
+			})
+			assert.Error(t, err, "refresh")
+
+			sessions, err := env.sr.GetActiveByUserID(context.Background(), user.ID.Hex())
+			require.NoError(t, err)
+			assert.Empty(t, sessions)
+
+			// Other users keep their tokens
+			_, err = env.jwt.VerifyAccessToken(untouched.AccessToken)
+			assert.NoError(t, err)
+
+			// Tokens issued afterwards work again
+			issued, err = issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+			require.NoError(t, err)
+
+			_, err = env.jwt.VerifyAccessToken(issued.AccessToken)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUserUpdateKeepsTokens(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	err = uc.Update(context.Background(), domain.UpdateUser{ID: user.ID, Name: "Renamed", Role: user.Role, IsActive: true})
+	require.NoError(t, err)
+
+	_, err = env.jwt.VerifyAccessToken(issued.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenReuse(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	refresh := func(res domain.AuthResponse) (domain.AuthResponse, error) {
+		return uc.RefreshToken(context.Background(), domain.RefreshTokenRequest{
+			AccessToken:  res.AccessToken,
+			RefreshToken: res.RefreshToken,
+		})
+	}
+
+	first, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	// A separate sign in starts a family of its own
+	elsewhere, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	second, err := refresh(first)
+	require.NoError(t, err)
+
+	third, err := refresh(second)
+	require.NoError(t, err)
+
+	// Presenting a rotated token again gives the family away
+	_, err = refresh(first)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, err.Error(), constants.REFRESH_TOKEN_REUSED)
+
+	_, err = refresh(third)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "latest refresh token")
+
+	_, err = env.jwt.VerifyAccessToken(third.AccessToken)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "latest access token")
+
+	require.Len(t, env.ser.events, 1)
+	assert.Equal(t, domain.SECURITY_EVENT_REFRESH_TOKEN_REUSE, env.ser.events[0].Type)
+	assert.Equal(t, user.ID.Hex(), env.ser.events[0].UserID)
+
+	_, err = refresh(elsewhere)
+	assert.NoError(t, err, "other family")
+}
+
+func TestRefreshTokenConcurrentUse(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		refreshed int
+	)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := uc.RefreshToken(context.Background(), domain.RefreshTokenRequest{
+				AccessToken:  issued.AccessToken,
+				RefreshToken: issued.RefreshToken,
+			})
+			if err == nil {
+				mu.Lock()
+				refreshed++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, refreshed)
+}
+
+// failingPasswordStore loses every password update
+type failingPasswordStore struct {
+	*fakeUserRepository
+}
+
+func (r failingPasswordStore) UpdatePassword(ctx context.Context, id, password string) error {
+	return errors.New("connection reset")
+}
+
+func TestLoginRehashesPassword(t *testing.T) {
+	env := newTestEnv(t)
+
+	roleName, err := env.roles.RoleName(context.Background(), int8(domain.CUSTOMER))
+	require.NoError(t, err)
+
+	env.cfg.Portals = portal.Config{"shop": {roleName}}
+
+	user := env.addUser(t, domain.CUSTOMER)
+
+	// The user still has a hash from before the switch to argon2id
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct-horse-battery"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, env.ur.UpdatePassword(context.Background(), user.ID.Hex(), string(legacy)))
+
+	env.cfg.Password.Hashing = password.HashConfig{
+		Algorithm:         password.ALGORITHM_ARGON2ID,
+		Argon2MemoryKiB:   64,
+		Argon2Time:        1,
+		Argon2Parallelism: 1,
+	}
+	policy, hasher := env.passwords(t)
+
+	core, logs := observer.New(zap.ErrorLevel)
+	newUsecase := func(ur UserRepository) *UserUsecase {
+		return NewUserUsecase(env.jwt, env.cfg, ur, env.phr, env.sr, env.ser, env.clr, env.cr, env.roles, policy, hasher, zap.New(core).Sugar(), env.timeout())
+	}
+
+	login := domain.LoginRequest{Portal: "shop", Email: user.Email, Password: "correct-horse-battery"}
+
+	// A lost update does not fail the login, it is logged and tried next time
+	_, err = newUsecase(failingPasswordStore{env.ur}).Login(context.Background(), login)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "failed to store rehashed password", logs.All()[0].ContextMap()["message"])
+
+	stored, err := env.ur.GetByID(context.Background(), user.ID.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, string(legacy), stored.Password)
+
+	_, err = newUsecase(env.ur).Login(context.Background(), login)
+	require.NoError(t, err)
+
+	stored, err = env.ur.GetByID(context.Background(), user.ID.Hex())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stored.Password, "$argon2id$"))
+	assert.False(t, hasher.NeedsRehash(stored.Password))
+	assert.Equal(t, 1, logs.Len())
+}
+
+// The session document refuses the refresh even when the cache lost the mark
+// revoking the session
+func TestRefreshTokenOfRevokedSession(t *testing.T) {
+	env := newTestEnv(t)
+	uc := env.userUsecase(t)
+	user := env.addUser(t, domain.CUSTOMER)
+
+	issued, err := issueTokens(context.Background(), env.jwt, env.cfg, env.sr, tokenRequest{user: user})
+	require.NoError(t, err)
+
+	claims, err := env.jwt.VerifyRefreshToken(issued.RefreshToken)
+	require.NoError(t, err)
+
+	require.NoError(t, env.sr.Revoke(context.Background(), claims["sid"].(string)))
+
+	_, err = uc.RefreshToken(context.Background(), domain.RefreshTokenRequest{
+		AccessToken:  issued.AccessToken,
+		RefreshToken: issued.RefreshToken,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Contains(t, err.Error(), constants.REFRESH_TOKEN_EXPIRED)
+}